@@ -0,0 +1,199 @@
+package material
+
+import "fmt"
+
+// Role is the kind of element a sheet is to assistive technologies.
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleButton
+	RoleHeading
+	RoleText
+	RoleImage
+	RoleMenu
+	RoleMenuItem
+	RoleCheckbox
+	RoleRadio
+	RoleSwitch
+	RoleSlider
+	RoleDialog
+)
+
+var roleNames = [...]string{"none", "button", "heading", "text", "image", "menu", "menuitem", "checkbox", "radio", "switch", "slider", "dialog"}
+
+func (r Role) String() string {
+	if r < 0 || int(r) >= len(roleNames) {
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+	return roleNames[r]
+}
+
+// MarshalText encodes role by name.
+func (r Role) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// Node describes a visible sheet to assistive technologies.
+type Node struct {
+	ID    int    `json:"id"` // index of sheet in order added to environment, or -1 for root
+	Role  Role   `json:"role"`
+	Label string `json:"label,omitempty"`
+
+	Disabled bool `json:"disabled,omitempty"`
+	Selected bool `json:"selected,omitempty"`
+	Expanded bool `json:"expanded,omitempty"`
+
+	// Bounds is x, y, width, height in pixels with origin at bottom left, as
+	// drawn and touched.
+	Bounds [4]float32 `json:"bounds"`
+
+	Children []*Node `json:"children,omitempty"`
+}
+
+// container is implemented by sheets grouping other sheets of an environment,
+// such as the actions of a toolbar.
+type container interface {
+	contents() []Sheet
+}
+
+func (tb *Toolbar) contents() []Sheet {
+	sheets := []Sheet{tb.Nav, tb.Title}
+	for _, btn := range tb.actions {
+		sheets = append(sheets, btn)
+	}
+	return sheets
+}
+
+func (mu *Menu) contents() []Sheet {
+	sheets := make([]Sheet, len(mu.actions))
+	for i, btn := range mu.actions {
+		sheets[i] = btn
+	}
+	return sheets
+}
+
+func (dlg *Dialog) contents() []Sheet {
+	var sheets []Sheet
+	if dlg.close != nil {
+		sheets = append(sheets, dlg.close)
+	}
+	sheets = append(sheets, dlg.Title, dlg.Body)
+	for _, btn := range dlg.items {
+		sheets = append(sheets, btn)
+	}
+	for _, rb := range dlg.options {
+		sheets = append(sheets, rb)
+	}
+	for _, btn := range dlg.actions {
+		sheets = append(sheets, btn)
+	}
+	return sheets
+}
+
+func (sb *Snackbar) contents() []Sheet {
+	if sb.action == nil {
+		return nil
+	}
+	return []Sheet{sb.action}
+}
+
+// role returns Role of sheet if set, else role inferred from its type and
+// content.
+func role(sheet Sheet) Role {
+	m := sheet.M()
+	if m.Role != RoleNone {
+		return m.Role
+	}
+	switch sheet.(type) {
+	case *Button, *FloatingActionButton:
+		return RoleButton
+	case *Menu:
+		return RoleMenu
+	case *Checkbox:
+		return RoleCheckbox
+	case *RadioButton:
+		return RoleRadio
+	case *Switch:
+		return RoleSwitch
+	case *Slider:
+		return RoleSlider
+	case *Dialog:
+		return RoleDialog
+	}
+	switch {
+	case m.text.value != "":
+		return RoleText
+	case m.icon.x != -1:
+		return RoleImage
+	}
+	return RoleNone
+}
+
+// AccessibilityTree returns tree of visible sheets as described to assistive
+// technologies, rooted at a node bounding the environment. Sheets grouped by
+// another, such as menu actions, are its children; parts of other sheets are
+// omitted unless grouped, as are sheets without role, label or children.
+// AccessibilityTree should be called after layout.
+func (env *Environment) AccessibilityTree() *Node {
+	sheets := env.addedOrder()
+	added := make(map[Sheet]bool, len(sheets))
+	for _, sheet := range sheets {
+		added[sheet] = true
+	}
+	owned := make(map[Sheet]bool)
+	for _, sheet := range sheets {
+		if c, ok := sheet.(container); ok {
+			for _, s := range c.contents() {
+				owned[s] = true
+			}
+		}
+	}
+
+	var node func(sheet Sheet) *Node
+	node = func(sheet Sheet) *Node {
+		m := sheet.M()
+		if m.Hidden() {
+			return nil
+		}
+		x, y := m.at()
+		n := &Node{
+			ID:       m.added,
+			Role:     role(sheet),
+			Label:    m.Label,
+			Disabled: m.state&StateDisabled != 0,
+			Selected: m.state&StateSelected != 0,
+			Expanded: m.state&StateExpanded != 0,
+			Bounds:   [4]float32{x, y, m.world[0][0], m.world[1][1]},
+		}
+		if n.Label == "" {
+			n.Label = m.text.value
+		}
+		if c, ok := sheet.(container); ok {
+			for _, s := range c.contents() {
+				if !added[s] {
+					continue
+				}
+				if child := node(s); child != nil {
+					n.Children = append(n.Children, child)
+				}
+			}
+		}
+		if n.Role == RoleNone && n.Label == "" && len(n.Children) == 0 {
+			return nil
+		}
+		return n
+	}
+
+	root := &Node{
+		ID:     -1,
+		Bounds: [4]float32{env.Box.world[0][3], env.Box.world[1][3], env.Box.world[0][0], env.Box.world[1][1]},
+	}
+	for _, sheet := range sheets {
+		if owned[sheet] || sheet.M().owner != nil {
+			continue
+		}
+		if n := node(sheet); n != nil {
+			root.Children = append(root.Children, n)
+		}
+	}
+	return root
+}
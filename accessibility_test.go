@@ -0,0 +1,57 @@
+package material
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+)
+
+func TestEnvironmentAccessibilityTree(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+
+	env.NewMaterial(nil) // omitted without role or label
+	mu := env.NewMenu(nil)
+	open := env.NewButton(nil)
+	open.SetText("Open")
+	mu.AddAction(open)
+	quit := env.NewButton(nil)
+	quit.Label = "Quit"
+	quit.SetState(StateDisabled, true)
+	mu.AddAction(quit)
+	mu.hidden, open.hidden, quit.hidden = false, false, false
+	fab := env.NewFloatingActionButton(nil)
+	fab.Hide()
+
+	root := env.AccessibilityTree()
+	if len(root.Children) != 1 {
+		t.Fatalf("have %v root nodes, want menu only", len(root.Children))
+	}
+	n := root.Children[0]
+	if n.Role != RoleMenu || len(n.Children) != 2 {
+		t.Fatalf("have %v with %v children, want menu with 2", n.Role, len(n.Children))
+	}
+	if c := n.Children[0]; c.Role != RoleMenuItem || c.Label != "Open" || c.Disabled {
+		t.Errorf("have %+v", c)
+	}
+	if c := n.Children[1]; c.Label != "Quit" || !c.Disabled {
+		t.Errorf("have %+v", c)
+	}
+
+	mu.world[2][3] = 8
+	sort.Sort(byZ(env.sheets)) // as Draw does
+	if id := env.AccessibilityTree().Children[0].ID; id != 1 {
+		t.Errorf("have menu id %v after sorting by z, want 1 as added", id)
+	}
+
+	b, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); !strings.Contains(s, `"role":"menuitem"`) {
+		t.Errorf("roles not encoded by name: %s", s)
+	}
+}
@@ -1,15 +1,12 @@
 package material
 
-import (
-	"golang.org/x/mobile/exp/f32"
-	"golang.org/x/mobile/gl"
-)
-
+// Behavior is a set of flags describing how a surface responds to screen
+// changes and user interaction.
 type Behavior int
 
 const (
 	// When screen space is available, a surface is always visible.
-	VisibilityPermanent Behavior = iota
+	VisibilityPermanent Behavior = 1 << iota
 
 	// Surface visibility can be toggled between visible and hidden. When visible,
 	// interacting with other elements on the screen does not change visibility.
@@ -44,54 +41,150 @@ const (
 	DescriptorRaised
 )
 
+// ScrimColor is drawn over content beneath a visible temporary surface.
+var ScrimColor = Black.WithAlpha(82) // 32%
+
+// temporary reports whether sheet is dismissed by interacting elsewhere on screen.
+// A permanent surface without room to be shown behaves as temporary.
+func temporary(sheet Sheet) bool {
+	m := sheet.M()
+	return m.BehaviorFlags&VisibilityTemporary != 0 || m.collapsed
+}
+
+// fits reports whether solved material lies within environment bounds.
+func (env *Environment) fits(m *Material) bool {
+	x, w := m.world[0][3], m.world[0][0]
+	return x >= 0 && x+w <= float32(windowSize.WidthPx)
+}
+
+// collapse shows permanent surface m if solved within environment bounds,
+// otherwise hides it to behave as temporary.
+func (env *Environment) collapse(m *Material) {
+	if m.BehaviorFlags&VisibilityPermanent != 0 {
+		m.collapsed = !env.fits(m)
+		m.hidden = m.collapsed
+	}
+}
+
+// Show makes sheet visible. Permanent surfaces with room are always visible.
+func (env *Environment) Show(sheet Sheet) {
+	if sheet.Hidden() {
+		sheet.Show()
+	}
+}
+
+// Hide hides sheet unless it is a permanent surface with room to be shown.
+func (env *Environment) Hide(sheet Sheet) {
+	if m := sheet.M(); m.BehaviorFlags&VisibilityPermanent != 0 && !m.collapsed {
+		return
+	}
+	if !sheet.Hidden() {
+		sheet.Hide()
+	}
+}
+
+// Toggle shows sheet if hidden, otherwise sheet is hidden.
+func (env *Environment) Toggle(sheet Sheet) {
+	if sheet.Hidden() {
+		env.Show(sheet)
+	} else {
+		env.Hide(sheet)
+	}
+}
+
+// dismissTemporary hides visible temporary surfaces not containing x, y and
+// reports whether any were hidden. Dialogs are dismissed by dismissModal and
+// menus already sliding out are ignored.
+func (env *Environment) dismissTemporary(x, y float32) (ok bool) {
+	for _, sheet := range env.sheets {
+		switch sheet := sheet.(type) {
+		case *Dialog:
+			continue
+		case *Menu:
+			if sheet.closing {
+				continue
+			}
+		}
+		if !sheet.Hidden() && temporary(sheet) && !sheet.Contains(x, y) {
+			sheet.Hide()
+			ok = true
+		}
+	}
+	return ok
+}
+
+// appendSheets appends env.sheets, sorted by z, to dst with scrim inserted
+// beneath the top-most visible temporary surface.
+func (env *Environment) appendSheets(dst []Sheet) []Sheet {
+	top := -1
+	for i, sheet := range env.sheets {
+		if !sheet.Hidden() && temporary(sheet) {
+			top = i
+		}
+	}
+	if top == -1 {
+		return append(dst, env.sheets...)
+	}
+
+	if env.scrim == nil {
+		env.scrim = New(nil, ScrimColor)
+		env.scrim.overlay = true
+	}
+	env.scrim.world.Identity()
+	env.scrim.world[0][0] = float32(windowSize.WidthPx)
+	env.scrim.world[1][1] = float32(windowSize.HeightPx)
+	env.scrim.world[2][3] = env.sheets[top].M().world[2][3]
+	env.scrim.fade = 1 - env.sheets[top].M().opacity()
+
+	dst = append(dst, env.sheets[:top]...)
+	dst = append(dst, env.scrim)
+	return append(dst, env.sheets[top:]...)
+}
+
 type Grid struct {
 	Margin  float32
 	Gutter  float32
 	Columns int
 
-	debug *Material
+	Breakpoint Breakpoint
 }
 
 func (gd *Grid) StepSize() float32 {
 	return (float32(windowSize.WidthPx) - (gd.Margin * 2)) / float32(gd.Columns)
 }
 
-// TODO avoid the pointer
-func NewGrid() *Grid {
-	// by breakpoints
-	g := &Grid{Margin: 24, Gutter: 24, Columns: 12}
-	if windowSize.WidthPx < int(Dp(600).Px()) || windowSize.HeightPx < int(Dp(600).Px()) {
-		g.Margin, g.Gutter = 16, 16 // TODO dp vals
-	}
-	if windowSize.WidthPx < int(Dp(480).Px()) {
-		g.Columns = 4
-	} else if windowSize.WidthPx < int(Dp(720).Px()) {
-		g.Columns = 8
-	}
-	return g
+// ColumnWidth returns width of a single column, excluding gutters.
+func (gd *Grid) ColumnWidth() float32 {
+	w := float32(windowSize.WidthPx) - gd.Margin*2 - gd.Gutter*float32(gd.Columns-1)
+	return w / float32(gd.Columns)
 }
 
-func (gd *Grid) draw(ctx gl.Context, view, proj f32.Mat4) {
-	if gd.debug == nil {
-		gd.debug = New(ctx, Color(0x03A9F499))
-		gd.debug.world.Identity()
-		gd.debug.world[0][0] = gd.Gutter + gd.Margin
-		gd.debug.world[1][1] = float32(windowSize.HeightPx)
-	}
+// SpanWidth returns width of n consecutive columns, including gutters between them.
+// Value of n is clamped to range [1..gd.Columns].
+func (gd *Grid) SpanWidth(n int) float32 {
+	n = gd.clamp(n, 1)
+	return float32(n)*gd.ColumnWidth() + float32(n-1)*gd.Gutter
+}
 
-	step := gd.StepSize()
-	for i := 0; i <= gd.Columns; i++ {
-		if i == 0 {
-			gd.debug.world[0][0] = gd.Margin
-			gd.debug.world[0][3] = 0
-		} else if i == gd.Columns {
-			gd.debug.world[0][0] = gd.Margin
-			gd.debug.world[0][3] = gd.Margin + float32(i)*step
-		} else {
-			gd.debug.world[0][0] = gd.Gutter
-			gd.debug.world[0][3] = gd.Margin + float32(i)*step - gd.Gutter/2.0
-		}
-		// TODO add to environment
-		// gd.debug.Draw(ctx, view, proj)
+// ColumnStart returns offset of column i, zero-based, from the left edge of window.
+// Value of i is clamped to range [0..gd.Columns-1].
+func (gd *Grid) ColumnStart(i int) float32 {
+	i = gd.clamp(i+1, 1) - 1
+	return gd.Margin + float32(i)*(gd.ColumnWidth()+gd.Gutter)
+}
+
+func (gd *Grid) clamp(n, min int) int {
+	if n < min {
+		return min
 	}
+	if n > gd.Columns {
+		return gd.Columns
+	}
+	return n
+}
+
+// NewGrid returns layout grid for current window size by DefaultBreakpoints.
+// TODO avoid the pointer
+func NewGrid() *Grid {
+	return DefaultBreakpoints.Grid()
 }
@@ -0,0 +1,148 @@
+package material
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dskinner/simplex"
+
+	"golang.org/x/mobile/event/size"
+)
+
+func TestGridColumns(t *testing.T) {
+	windowSize = size.Event{WidthPx: 360, HeightPx: 640, PixelsPerPt: 1}
+	gd := &Grid{Margin: 16, Gutter: 16, Columns: 4}
+
+	if have, want := gd.ColumnWidth(), float32(70); have != want {
+		t.Errorf("ColumnWidth: have %v, want %v", have, want)
+	}
+	if have, want := gd.SpanWidth(4), float32(360-2*16); have != want {
+		t.Errorf("SpanWidth(4): have %v, want %v", have, want)
+	}
+	if have, want := gd.SpanWidth(2), float32(70*2+16); have != want {
+		t.Errorf("SpanWidth(2): have %v, want %v", have, want)
+	}
+	if have, want := gd.SpanWidth(9), gd.SpanWidth(4); have != want {
+		t.Errorf("SpanWidth(9): have %v, want %v", have, want)
+	}
+	if have, want := gd.ColumnStart(2), float32(16+2*(70+16)); have != want {
+		t.Errorf("ColumnStart(2): have %v, want %v", have, want)
+	}
+	if have, want := gd.ColumnStart(3)+gd.ColumnWidth(), float32(360-16); have != want {
+		t.Errorf("end of last column: have %v, want %v", have, want)
+	}
+
+	a, b := NewBox(new(simplex.Program)), NewBox(new(simplex.Program))
+	if have, want := a.Columns(b, gd, 3, 4), a.Columns(b, gd, 3, 1); !reflect.DeepEqual(have, want) {
+		t.Errorf("Columns(3, 4): have %v, want %v", have, want)
+	}
+}
+
+func TestMaterialSpanFor(t *testing.T) {
+	mtrl := New(nil, White)
+	mtrl.Span(2, 4, 0)
+	for _, tt := range []struct{ columns, span int }{{4, 2}, {6, 2}, {8, 4}, {12, 12}, {16, 16}} {
+		if have := mtrl.SpanFor(&Grid{Columns: tt.columns}); have != tt.span {
+			t.Errorf("%v columns: have span %v, want %v", tt.columns, have, tt.span)
+		}
+	}
+}
+
+func TestVisibilityBehavior(t *testing.T) {
+	windowSize = size.Event{WidthPx: 400, HeightPx: 400, PixelsPerPt: 1}
+	for _, tt := range []struct {
+		name  string
+		flags Behavior
+		x     float32 // of sheet 200 wide
+		// hidden after layout, after shown and touched outside, and toggled
+		laid, touched, toggled bool
+	}{
+		{"permanent", VisibilityPermanent, 0, false, false, false},
+		{"permanent without room", VisibilityPermanent, 300, true, true, false},
+		{"persistent", VisibilityPersistent, 0, true, false, true},
+		{"temporary", VisibilityTemporary, 0, true, true, false},
+	} {
+		env := new(Environment)
+		m := env.NewMaterial(nil)
+		m.BehaviorFlags = tt.flags
+		m.world[0][3], m.world[0][0], m.world[1][1] = tt.x, 200, 200
+		m.hidden = true
+
+		env.collapse(m)
+		if m.Hidden() != tt.laid {
+			t.Errorf("%s: have hidden %v after layout, want %v", tt.name, m.Hidden(), tt.laid)
+		}
+		env.Show(m)
+		tap(env, 350, 350)
+		if m.Hidden() != tt.touched {
+			t.Errorf("%s: have hidden %v after touch outside, want %v", tt.name, m.Hidden(), tt.touched)
+		}
+		env.Toggle(m)
+		if m.Hidden() != tt.toggled {
+			t.Errorf("%s: have hidden %v after toggle, want %v", tt.name, m.Hidden(), tt.toggled)
+		}
+	}
+}
+
+func TestMenuDismiss(t *testing.T) {
+	windowSize = size.Event{WidthPx: 400, HeightPx: 400, PixelsPerPt: 1}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	mu := env.NewMenu(nil)
+	btn := env.NewButton(nil)
+	mu.AddAction(btn)
+	mu.world[0][0], mu.world[1][1] = 100, 32
+	mu.world[1][3] = 150
+
+	mu.Show()
+	mu.Show() // before animation starts
+	step(env, clock, 300*time.Millisecond)
+	if _, y := mu.at(); y != 118 {
+		t.Fatalf("have y %v after shown, want 118", y)
+	}
+
+	tap(env, 350, 350)
+	step(env, clock, 50*time.Millisecond)
+	tap(env, 350, 350) // while sliding out
+	step(env, clock, 100*time.Millisecond)
+	if !mu.Hidden() || !btn.Hidden() {
+		t.Errorf("have hidden %v, %v after touches outside, want hidden", mu.Hidden(), btn.Hidden())
+	}
+	if _, y := mu.at(); y != 150 {
+		t.Errorf("have menu y %v after hidden, want 150", y)
+	}
+	if _, y := btn.at(); y != btn.world[1][3] {
+		t.Errorf("have action y %v after hidden, want %v", y, btn.world[1][3])
+	}
+}
+
+func TestScrimBeneathTemporary(t *testing.T) {
+	windowSize = size.Event{WidthPx: 400, HeightPx: 400, PixelsPerPt: 1}
+	env := new(Environment)
+	for _, z := range []float32{0, 8, 16} {
+		m := env.NewMaterial(nil)
+		m.world[2][3] = z
+	}
+	if n := len(env.appendSheets(nil)); n != 3 {
+		t.Fatalf("have %v sheets drawn without temporary surface, want 3", n)
+	}
+
+	tmp := env.sheets[1].M()
+	tmp.BehaviorFlags |= VisibilityTemporary
+	drawn := env.appendSheets(nil)
+	if len(drawn) != 4 || drawn[1] != env.scrim || drawn[2] != env.sheets[1] {
+		t.Fatalf("scrim not drawn beneath temporary surface")
+	}
+	if z := env.scrim.world[2][3]; z != 8 {
+		t.Errorf("have scrim z %v, want 8", z)
+	}
+	if w, h := env.scrim.world[0][0], env.scrim.world[1][1]; w != 400 || h != 400 {
+		t.Errorf("have scrim %v by %v, want window", w, h)
+	}
+
+	tmp.Hide()
+	if n := len(env.appendSheets(nil)); n != 3 {
+		t.Errorf("have %v sheets drawn with temporary surface hidden, want 3", n)
+	}
+}
@@ -1,32 +1,38 @@
 package material
 
 import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	// Do we need this whole snd package for the functionality used here?
-	"github.com/dskinner/snd"
 	"golang.org/x/mobile/exp/f32"
 )
 
+// ExpSig and LinSig are curves formerly sampled from snd decay tables.
+//
+// Deprecated: use Decelerate and Linear.
 var (
-	ExpSig, LinSig snd.Discrete
+	ExpSig Curve = Decelerate
+	LinSig Curve = Linear
 )
 
-func init() {
-	ExpSig = snd.ExpDecay()
-	ExpSig.UnitInverse()
-	LinSig = snd.LinearDecay()
-	LinSig.UnitInverse()
+// Curve maps unit progress of an animation to an interpolated value. The value
+// is usually in range [0..1] but may overshoot, such as with Spring.
+type Curve interface {
+	At(t float64) float64
 }
 
 type Interpolator struct {
-	Sig  snd.Discrete
+	Sig  Curve
 	Dur  time.Duration
 	Loop bool
 }
 
 type Animation struct {
-	Sig    snd.Discrete
+	Sig    Curve
 	Dur    time.Duration
 	Loop   bool
 	Start  func()
@@ -34,6 +40,11 @@ type Animation struct {
 	End    func()
 }
 
+// Do runs animation on its own goroutine, stepped every 16ms until a value is
+// sent on quit.
+//
+// Deprecated: Do mutates state concurrently with Environment.Draw. Use
+// Environment.Animate instead, which steps animations from Draw.
 func (anim Animation) Do() (quit chan struct{}) {
 	quit = make(chan struct{}, 1)
 	go func() {
@@ -67,6 +78,10 @@ func (anim Animation) Do() (quit chan struct{}) {
 	return quit
 }
 
+// Animate calls fn with a copy of mat on its own goroutine.
+//
+// Deprecated: Animate mutates state concurrently with Environment.Draw. Use
+// Environment.Animate instead, which steps animations from Draw.
 func Animate(mat *f32.Mat4, interp Interpolator, fn func(m *f32.Mat4, dt float32)) (quit chan struct{}) {
 	m := *mat // copy; translate is always relative to resting position
 	quit = make(chan struct{}, 1)
@@ -98,3 +113,357 @@ func AnimateRotate(angle f32.Radian, axis f32.Vec3, mat *f32.Mat4, interp Interp
 		mat.Rotate(m, f32.Radian(dt*float32(angle)), &axis)
 	})
 }
+
+// Clock reports the current time to a Scheduler.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// SystemClock reports time.Now.
+var SystemClock Clock = systemClock{}
+
+// Scheduler steps animations once per frame. Environment owns a scheduler that
+// is stepped at the start of each call to Draw so animations mutate sheets on
+// the same goroutine that draws them.
+//
+// Start, Pause, Resume and Handle.Cancel are safe to call from any goroutine.
+// Step must only be called from the goroutine drawing the animated sheets.
+type Scheduler struct {
+	mu    sync.Mutex
+	clock Clock
+
+	last    time.Time     // clock time of last step
+	elapsed time.Duration // running time, excluding time paused
+	paused  bool
+
+	pending []*Handle // started since last step
+	handles []*Handle
+}
+
+// NewScheduler returns scheduler reading time from clock. If clock is nil,
+// SystemClock is used.
+func NewScheduler(clock Clock) *Scheduler {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Scheduler{clock: clock, last: clock.Now()}
+}
+
+// Reason describes why an animation ended.
+type Reason int
+
+const (
+	Running   Reason = iota // animation has not ended
+	Finished                // played to its end, or its beginning if reversed
+	Cancelled               // cancelled, or preceding animation was cancelled
+)
+
+func (r Reason) String() string {
+	switch r {
+	case Running:
+		return "running"
+	case Finished:
+		return "finished"
+	case Cancelled:
+		return "cancelled"
+	}
+	return "Reason(" + strconv.Itoa(int(r)) + ")"
+}
+
+// Handle controls an animation scheduled with Scheduler.Start.
+type Handle struct {
+	anim    Animation
+	sch     *Scheduler
+	started bool
+	done    bool
+
+	pivot time.Duration // scheduler time of last change in direction
+	at    float64       // progress at pivot
+	dir   float64       // 1 forwards, -1 backwards
+	last  time.Duration // scheduler time of last step
+
+	cancel  int32 // atomic
+	reverse int32 // atomic; pending reversals
+
+	mu       sync.Mutex // guards fields below
+	reason   Reason
+	ch       chan struct{}  // closed when ended
+	then     []func(Reason) // called when ended
+	children []*Handle      // cancelled with handle; see All
+}
+
+func newHandle(sch *Scheduler, anim Animation) *Handle {
+	return &Handle{anim: anim, sch: sch, ch: make(chan struct{})}
+}
+
+// Cancel stops animation at the next step. If animation has started, End is called.
+func (h *Handle) Cancel() {
+	atomic.StoreInt32(&h.cancel, 1)
+	h.mu.Lock()
+	children := h.children
+	h.mu.Unlock()
+	for _, c := range children {
+		c.Cancel()
+	}
+}
+
+// Reverse plays animation backwards from its progress at the last step, or
+// forwards again if already reversed. An animation played backwards ends when
+// its progress returns to zero.
+func (h *Handle) Reverse() { atomic.AddInt32(&h.reverse, 1) }
+
+// Done returns channel closed when animation ends, after End is called.
+func (h *Handle) Done() <-chan struct{} { return h.ch }
+
+// Reason returns why animation ended, or Running if it hasn't.
+func (h *Handle) Reason() Reason {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.reason
+}
+
+// Wait blocks until animation ends or ctx is done. Animations are stepped by
+// Draw, so Wait must not be called from the goroutine calling Draw.
+func (h *Handle) Wait(ctx context.Context) (Reason, error) {
+	select {
+	case <-h.ch:
+		return h.Reason(), nil
+	case <-ctx.Done():
+		return Running, ctx.Err()
+	}
+}
+
+// Then returns handle of anim, started on the step after h finishes. If h is
+// cancelled, anim is never started and its handle ends as cancelled.
+//
+//	env.Animate(hide).Then(Animation{Start: navigate})
+func (h *Handle) Then(anim Animation) *Handle {
+	next := newHandle(h.sch, anim)
+	h.onEnd(func(r Reason) {
+		if r == Finished && h.sch != nil {
+			h.sch.schedule(next)
+		} else {
+			next.end(Cancelled)
+		}
+	})
+	return next
+}
+
+// All returns handle ending when all hs end. Its reason is Finished if all hs
+// finished, otherwise Cancelled. Cancelling the returned handle cancels hs.
+// All panics if hs is empty.
+func All(hs ...*Handle) *Handle {
+	if len(hs) == 0 {
+		panic("material: All of no handles")
+	}
+	all := newHandle(hs[0].sch, Animation{})
+	all.children = hs
+
+	var mu sync.Mutex
+	n, reason := len(hs), Finished
+	for _, h := range hs {
+		h.onEnd(func(r Reason) {
+			mu.Lock()
+			n--
+			if r != Finished {
+				reason = Cancelled
+			}
+			last := n == 0
+			mu.Unlock()
+			if last {
+				all.end(reason)
+			}
+		})
+	}
+	return all
+}
+
+// onEnd calls fn with reason when h ends, immediately if h already ended.
+func (h *Handle) onEnd(fn func(Reason)) {
+	h.mu.Lock()
+	if h.reason == Running {
+		h.then = append(h.then, fn)
+		h.mu.Unlock()
+		return
+	}
+	r := h.reason
+	h.mu.Unlock()
+	fn(r)
+}
+
+// end records reason, closes Done channel and calls functions registered
+// with onEnd. Only the first call has any effect.
+func (h *Handle) end(r Reason) {
+	h.mu.Lock()
+	if h.reason != Running {
+		h.mu.Unlock()
+		return
+	}
+	h.reason = r
+	close(h.ch)
+	fns := h.then
+	h.then = nil
+	h.mu.Unlock()
+	for _, fn := range fns {
+		fn(r)
+	}
+}
+
+// progress returns unit progress of animation at scheduler time now; values
+// outside range [0..1] are possible.
+func (h *Handle) progress(now time.Duration) float64 {
+	if h.anim.Dur <= 0 {
+		if h.dir < 0 {
+			return 0
+		}
+		return 1
+	}
+	return h.at + h.dir*float64(now-h.pivot)/float64(h.anim.Dur)
+}
+
+// Start schedules anim to begin on next step.
+func (sch *Scheduler) Start(anim Animation) *Handle {
+	h := newHandle(sch, anim)
+	sch.schedule(h)
+	return h
+}
+
+func (sch *Scheduler) schedule(h *Handle) {
+	sch.mu.Lock()
+	sch.pending = append(sch.pending, h)
+	sch.mu.Unlock()
+}
+
+// Pause stops time for all animations until Resume is called, such as when
+// the app is no longer visible.
+func (sch *Scheduler) Pause() {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	sch.advance()
+	sch.paused = true
+}
+
+// Resume continues animations from where they were paused.
+func (sch *Scheduler) Resume() {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	sch.last = sch.clock.Now()
+	sch.paused = false
+}
+
+// Paused reports whether scheduler is paused.
+func (sch *Scheduler) Paused() bool {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	return sch.paused
+}
+
+// Len returns number of animations scheduled.
+func (sch *Scheduler) Len() int {
+	sch.mu.Lock()
+	defer sch.mu.Unlock()
+	return len(sch.handles) + len(sch.pending)
+}
+
+// advance must be called with sch.mu held.
+func (sch *Scheduler) advance() {
+	now := sch.clock.Now()
+	if !sch.paused {
+		sch.elapsed += now.Sub(sch.last)
+	}
+	sch.last = now
+}
+
+// Step advances each animation to the current time of clock, calling Start on
+// animations not yet started and End on animations completed or cancelled.
+func (sch *Scheduler) Step() {
+	sch.mu.Lock()
+	sch.advance()
+	if sch.paused {
+		sch.mu.Unlock()
+		return
+	}
+	now := sch.elapsed
+	sch.handles = append(sch.handles, sch.pending...)
+	for i := range sch.pending {
+		sch.pending[i] = nil
+	}
+	sch.pending = sch.pending[:0]
+	handles := sch.handles
+	sch.mu.Unlock()
+
+	// animation callbacks may schedule more animations without deadlock;
+	// those are pending until the next step.
+	for _, h := range handles {
+		h.step(now)
+	}
+
+	sch.mu.Lock()
+	n := 0
+	for _, h := range sch.handles {
+		if !h.done {
+			sch.handles[n] = h
+			n++
+		}
+	}
+	for i := n; i < len(sch.handles); i++ {
+		sch.handles[i] = nil
+	}
+	sch.handles = sch.handles[:n]
+	sch.mu.Unlock()
+}
+
+func (h *Handle) step(now time.Duration) {
+	if h.done {
+		return
+	}
+	anim := h.anim
+	if atomic.LoadInt32(&h.cancel) == 1 {
+		h.done = true
+		if h.started && anim.End != nil {
+			anim.End()
+		}
+		h.end(Cancelled)
+		return
+	}
+	if !h.started {
+		h.started = true
+		h.pivot, h.dir, h.last = now, 1, now
+		if anim.Start != nil {
+			anim.Start()
+		}
+	}
+	if atomic.SwapInt32(&h.reverse, 0)%2 == 1 {
+		h.at, h.pivot, h.dir = h.progress(h.last), h.last, -h.dir
+	}
+	h.last = now
+
+	t := h.progress(now)
+	switch {
+	case anim.Loop:
+		t -= math.Floor(t)
+	case t >= 1:
+		t = 1
+		h.done = true
+	case t <= 0 && h.dir < 0:
+		t = 0
+		h.done = true
+	}
+	if anim.Interp != nil {
+		dt := float32(t)
+		if anim.Sig != nil {
+			dt = float32(anim.Sig.At(t))
+		}
+		anim.Interp(dt)
+	}
+	if h.done {
+		if anim.End != nil {
+			anim.End()
+		}
+		h.end(Finished)
+	}
+}
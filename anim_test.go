@@ -0,0 +1,148 @@
+package material
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testClock struct{ t time.Time }
+
+func (c *testClock) Now() time.Time      { return c.t }
+func (c *testClock) Add(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestSchedulerStep(t *testing.T) {
+	clock := new(testClock)
+	sch := NewScheduler(clock)
+
+	var starts, ends int
+	var last float32
+	sch.Start(Animation{
+		Dur:    100 * time.Millisecond,
+		Start:  func() { starts++ },
+		Interp: func(dt float32) { last = dt },
+		End:    func() { ends++ },
+	})
+
+	sch.Step()
+	if starts != 1 || last != 0 {
+		t.Fatalf("have starts %v at %v, want 1 at 0", starts, last)
+	}
+
+	clock.Add(50 * time.Millisecond)
+	sch.Step()
+	if last != 0.5 {
+		t.Errorf("have %v, want 0.5", last)
+	}
+
+	sch.Pause()
+	clock.Add(time.Second)
+	sch.Step()
+	sch.Resume()
+	clock.Add(25 * time.Millisecond)
+	sch.Step()
+	if last != 0.75 || ends != 0 {
+		t.Errorf("have %v with %v ends after pause, want 0.75 with 0", last, ends)
+	}
+
+	clock.Add(time.Second)
+	sch.Step()
+	if last != 1 || ends != 1 || sch.Len() != 0 {
+		t.Errorf("have %v with %v ends and %v scheduled, want 1 with 1 and 0", last, ends, sch.Len())
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	clock := new(testClock)
+	sch := NewScheduler(clock)
+
+	var ends int
+	h := sch.Start(Animation{Dur: time.Second, Loop: true, End: func() { ends++ }})
+	sch.Step()
+	clock.Add(5 * time.Second)
+	sch.Step()
+	if ends != 0 {
+		t.Fatalf("looping animation ended")
+	}
+	h.Cancel()
+	sch.Step()
+	if ends != 1 || sch.Len() != 0 {
+		t.Errorf("have %v ends and %v scheduled, want 1 and 0", ends, sch.Len())
+	}
+}
+
+func TestHandleThenAll(t *testing.T) {
+	clock := new(testClock)
+	sch := NewScheduler(clock)
+
+	var order []string
+	anim := func(name string) Animation {
+		return Animation{Dur: 100 * time.Millisecond, End: func() { order = append(order, name) }}
+	}
+	a, b := sch.Start(anim("a")), sch.Start(anim("b"))
+	c := All(a, b).Then(anim("c"))
+	d := sch.Start(anim("d"))
+	e := d.Then(anim("e"))
+
+	sch.Step()
+	d.Cancel()
+	for i := 0; i < 3; i++ {
+		clock.Add(100 * time.Millisecond)
+		sch.Step()
+	}
+
+	if have, want := strings.Join(order, ""), "abdc"; have != want {
+		t.Errorf("have order %q, want %q", have, want)
+	}
+	for _, tt := range []struct {
+		h    *Handle
+		want Reason
+	}{{a, Finished}, {c, Finished}, {d, Cancelled}, {e, Cancelled}} {
+		select {
+		case <-tt.h.Done():
+		default:
+			t.Errorf("handle not done")
+		}
+		if r := tt.h.Reason(); r != tt.want {
+			t.Errorf("have reason %v, want %v", r, tt.want)
+		}
+	}
+	if sch.Len() != 0 {
+		t.Errorf("have %v animations, want 0", sch.Len())
+	}
+}
+
+func TestHandleWait(t *testing.T) {
+	clock := new(testClock)
+	sch := NewScheduler(clock)
+	h := sch.Start(Animation{Dur: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if r, err := h.Wait(ctx); r != Running || err != context.DeadlineExceeded {
+		t.Errorf("have %v, %v; want running, deadline exceeded", r, err)
+	}
+
+	go func() {
+		sch.Step()
+		clock.Add(time.Second)
+		sch.Step()
+	}()
+	if r, err := h.Wait(context.Background()); r != Finished || err != nil {
+		t.Errorf("have %v, %v; want finished", r, err)
+	}
+}
+
+func TestMenuWithoutEnvironment(t *testing.T) {
+	mu := &Menu{Material: New(nil, White)}
+	mu.hidden = true
+	mu.Show()
+	if mu.Hidden() {
+		t.Errorf("menu hidden after Show")
+	}
+	mu.Hide()
+	if !mu.Hidden() {
+		t.Errorf("menu visible after Hide")
+	}
+}
@@ -4,9 +4,6 @@
 #define pi 3.14159265359
 #define twopi 6.28318530718
 
-#define touchBegin 0.0
-#define touchMove 1.0
-#define touchEnd 2.0
 precision mediump float;
 
 // TODO pass this in some other way so sampler can be selected
@@ -22,8 +19,13 @@ uniform vec4 shadowColor;
 
 varying vec4 vtexcoord;
 varying vec4 vvertex;
+// xy is origin of ripple relative to material in pixels
+// z is radius of ripple, w is opacity of ripple
 varying vec4 vtouch;
 
+// rgb is color of ripple and state layer, a is opacity of state layer
+varying vec4 vink;
+
 // interpolated distance, and size values
 // x, y is unit value [0..1]
 // z, w is material width, height
@@ -157,6 +159,7 @@ void main() {
     float f = 1.0 + (-vvertex.z*0.1);
 
     gl_FragColor.a *= n.x*n.y/f;
+    gl_FragColor.a *= vcolor.a; // opacity of key or ambient light
     // gl_FragColor.a *= 10.0;
   } else { // draw material
     if (shouldcolor(vdist.xy, roundness)) {
@@ -167,34 +170,20 @@ void main() {
         float dist = 1.0-shade(vdist.xy, roundness);
         // fractional based on largest size, approximates a consistent value across resolutions
         float dt = (5.0/max(vdist.z, vdist.w));
-        gl_FragColor.a = 1.0-smoothstep(1.0-dt, 1.0, dist);
+        gl_FragColor.a *= 1.0-smoothstep(1.0-dt, 1.0, dist);
       }
 
-      // respond to touch with radial
-      const float dur = 200.0;
-      const float clr = 0.035;
-
-      float since = vtouch.w;
-      vec4 react = vec4(0.0);
-
-      // float d = length(vtouch.xy-vdist.xy);
-      // float d = length(vtouch.xx-vdist.xx); // horizontal sweep
-
-      const float magic = 100.0;
-      float d = length((vtouch.xy*vdist.zw)-(vdist.xy*vdist.zw));
-      d /= magic;
-
-      float t = since/dur;
-      if (d < 2.0*t) {
-        if (t < sqrt2) { // color in
-          react = vec4(clr);
-        } else if (t < pi) { // fade out
-          float fac = 1.0 - (t-sqrt2)/(pi-sqrt2);
-          react = vec4(fac*clr);
-        }
+      // state layer and ripple expanding from touch, composited over material
+      float ink = vink.a;
+      if (vtouch.w > 0.0) {
+        float d = length(vdist.xy*vdist.zw - vtouch.xy);
+        ink += vtouch.w*(1.0-smoothstep(vtouch.z-1.0, vtouch.z, d));
+      }
+      if (ink > 0.0) {
+        float a = ink + gl_FragColor.a*(1.0-ink);
+        gl_FragColor.rgb = (vink.rgb*ink + gl_FragColor.rgb*gl_FragColor.a*(1.0-ink))/a;
+        gl_FragColor.a = a;
       }
-
-      gl_FragColor += react;
     } else {
       discard;
     }
@@ -6,11 +6,13 @@ attribute vec4 color;
 // sample with xy, offset at zw
 attribute vec4 texcoord;
 
-// xy is relative position of originating touch event
-// z is state of touch event; begin (0), move (1), end (2)
-// w is timing information
+// xy is origin of ripple relative to material in pixels
+// z is radius of ripple, w is opacity of ripple
 attribute vec4 touch;
 
+// rgb is color of ripple and state layer, a is opacity of state layer
+attribute vec4 ink;
+
 // v0, v1 == 1 && v2, v3 == 0, interpolated in fragment shader
 // to determine location since all materials are drawn as single
 // mesh.
@@ -25,6 +27,7 @@ varying vec4 vtexcoord;
 varying vec4 vdist;
 varying vec4 vvertex;
 varying vec4 vtouch;
+varying vec4 vink;
 
 void main() {
 	// TODO review if fragment shader *really* needs access to z coord
@@ -40,4 +43,5 @@ void main() {
 	vvertex = vertex;
 	vdist = dist;
 	vtouch = touch;
+	vink = ink;
 }
@@ -0,0 +1,210 @@
+package material
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Rules checked by Audit.
+const (
+	RuleContrast   = "contrast"    // text or icon contrast with color drawn over
+	RuleTargetSize = "target-size" // size of touch target
+	RuleTextSize   = "text-size"   // height of text
+)
+
+// Opacity of text and icons as drawn by shader, before their own alpha.
+const (
+	textOpacity = 0.87
+	iconOpacity = 0.54
+)
+
+// AuditRules are thresholds checked by Audit. Dp values are converted to
+// pixels when checked, so windowSize must be set.
+type AuditRules struct {
+	// TextContrast is minimum contrast ratio of text, LargeTextContrast of text
+	// at least LargeText high, and IconContrast of icons.
+	TextContrast      float64 `json:"text_contrast"`
+	LargeTextContrast float64 `json:"large_text_contrast"`
+	IconContrast      float64 `json:"icon_contrast"`
+
+	LargeText Dp `json:"large_text"`
+	MinTarget Dp `json:"min_target"` // minimum width and height of interactive sheets
+	MinText   Dp `json:"min_text"`   // minimum text height
+}
+
+// WCAG AA thresholds and material minimums for touch targets and type size.
+// https://www.w3.org/TR/WCAG21/#contrast-minimum
+// https://material.io/design/usability/accessibility.html#layout-typography
+var DefaultAuditRules = AuditRules{
+	TextContrast:      4.5,
+	LargeTextContrast: 3,
+	IconContrast:      3,
+	LargeText:         24,
+	MinTarget:         48,
+	MinText:           12,
+}
+
+// Issue is a sheet failing an audit rule. Have is the measured value and Want
+// the threshold; contrast ratios for RuleContrast, dp otherwise.
+type Issue struct {
+	Rule   string     `json:"rule"`
+	Sheet  int        `json:"sheet"`          // index in order added to environment
+	Name   string     `json:"name,omitempty"` // name bound in layout description
+	Type   string     `json:"type"`
+	Bounds [4]float32 `json:"bounds"` // x, y, width, height in pixels, as drawn
+	Have   float64    `json:"have"`
+	Want   float64    `json:"want"`
+	Detail string     `json:"detail,omitempty"`
+}
+
+func (is Issue) String() string {
+	s := fmt.Sprintf("sheet %v (%s", is.Sheet, is.Type)
+	if is.Name != "" {
+		s += " " + is.Name
+	}
+	s += fmt.Sprintf("): %s %.2f, want %.2f", is.Rule, is.Have, is.Want)
+	if is.Detail != "" {
+		s += " (" + is.Detail + ")"
+	}
+	return s
+}
+
+// Report is result of an audit, encodable as JSON for tooling.
+type Report struct {
+	Rules  AuditRules `json:"rules"`
+	Issues []Issue    `json:"issues"`
+}
+
+// Err returns an error listing issues of report, or nil if there are none.
+func (r Report) Err() error {
+	if len(r.Issues) == 0 {
+		return nil
+	}
+	lines := make([]string, len(r.Issues))
+	for i, is := range r.Issues {
+		lines[i] = is.String()
+	}
+	return fmt.Errorf("material: %v accessibility issues:\n%s", len(r.Issues), strings.Join(lines, "\n"))
+}
+
+// Audit checks visible sheets of environment against DefaultAuditRules. Audit
+// should be called after layout.
+func (env *Environment) Audit() Report {
+	return env.AuditWith(DefaultAuditRules)
+}
+
+// AuditWith checks visible sheets of environment against rules.
+func (env *Environment) AuditWith(rules AuditRules) Report {
+	rep := Report{Rules: rules, Issues: []Issue{}}
+	th := env.Theme()
+	dp := float64(Dp(1).Px())
+	if dp == 0 {
+		dp = 1
+	}
+
+	for _, sheet := range env.addedOrder() {
+		m := sheet.M()
+		if m.Hidden() {
+			continue
+		}
+		x, y := m.at()
+		issue := func(rule string, have, want float64, detail string) {
+			rep.Issues = append(rep.Issues, Issue{
+				Rule:   rule,
+				Sheet:  m.added,
+				Name:   env.nameOf(sheet),
+				Type:   strings.TrimPrefix(fmt.Sprintf("%T", sheet), "*material."),
+				Bounds: [4]float32{x, y, m.world[0][0], m.world[1][1]},
+				Have:   have,
+				Want:   want,
+				Detail: detail,
+			})
+		}
+
+		bg := env.backdrop(m, th)
+
+		if m.text.value != "" {
+			h := m.text.height
+			if h == 0 {
+				h = m.world[1][1]
+			}
+			size := float64(h) / dp
+			if size < float64(rules.MinText) {
+				issue(RuleTextSize, size, float64(rules.MinText), "")
+			}
+			want := rules.TextContrast
+			if size >= float64(rules.LargeText) {
+				want = rules.LargeTextContrast
+			}
+			r, g, b, a := m.textColor(th)
+			fg := colorOf(r, g, b, a*textOpacity)
+			if c := Contrast(fg.Over(bg), bg); c < want {
+				issue(RuleContrast, c, want, fmt.Sprintf("text %v over %v", fg, bg))
+			}
+		}
+
+		if m.icon.x != -1 {
+			r, g, b, a := m.iconColor(th)
+			fg := colorOf(r, g, b, a*iconOpacity)
+			if c := Contrast(fg.Over(bg), bg); c < rules.IconContrast {
+				issue(RuleContrast, c, rules.IconContrast, fmt.Sprintf("icon %v over %v", fg, bg))
+			}
+		}
+
+		if interactive(sheet) {
+			w, h := float64(m.world[0][0])/dp, float64(m.world[1][1])/dp
+			if min := math.Min(w, h); min < float64(rules.MinTarget) {
+				issue(RuleTargetSize, min, float64(rules.MinTarget), fmt.Sprintf("%.0fx%.0fdp", w, h))
+			}
+		}
+	}
+	return rep
+}
+
+// interactive reports whether sheet handles touch events.
+func interactive(sheet Sheet) bool {
+	switch sheet.(type) {
+	case *Button, *FloatingActionButton, *Checkbox, *RadioButton, *Switch, *Slider:
+		return true
+	}
+	return false
+}
+
+// backdrop returns color content of mtrl is drawn over; the fill of mtrl if
+// raised, else the fill of the topmost raised sheet beneath its center, else
+// background color of th.
+func (env *Environment) backdrop(mtrl *Material, th Theme) Color {
+	if mtrl.BehaviorFlags&DescriptorRaised != 0 {
+		return mtrl.background(th)
+	}
+	x, y := mtrl.at()
+	x, y = x+mtrl.world[0][0]/2, y+mtrl.world[1][1]/2
+	var under *Material
+	for _, sheet := range env.sheets {
+		m := sheet.M()
+		if m == mtrl || m.Hidden() || m.BehaviorFlags&DescriptorRaised == 0 || !m.Contains(x, y) {
+			continue
+		}
+		if m.Elevation() > mtrl.Elevation() {
+			continue
+		}
+		if under == nil || m.Elevation() >= under.Elevation() {
+			under = m
+		}
+	}
+	if under == nil {
+		return th.Background
+	}
+	return under.background(th)
+}
+
+// nameOf returns name sheet is bound to in environment, if any.
+func (env *Environment) nameOf(sheet Sheet) string {
+	for name, s := range env.names {
+		if s == sheet {
+			return name
+		}
+	}
+	return ""
+}
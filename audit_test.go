@@ -0,0 +1,95 @@
+package material
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+)
+
+func TestEnvironmentAudit(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+
+	btn := env.NewButton(nil)
+	btn.SetText("ok")
+	btn.world[0][0], btn.world[1][1] = 64, 48
+	btn.SetTextHeight(14)
+
+	if rep := env.Audit(); rep.Err() != nil {
+		t.Fatalf("unexpected issues: %v", rep.Err())
+	}
+
+	btn.world[1][1] = 32
+	btn.SetTextHeight(10)
+	btn.SetTextColor(Grey400)
+
+	rep := env.Audit()
+	rules := map[string]bool{}
+	for _, is := range rep.Issues {
+		rules[is.Rule] = true
+		if is.Type != "Button" {
+			t.Errorf("have type %q, want Button", is.Type)
+		}
+	}
+	for _, rule := range []string{RuleContrast, RuleTargetSize, RuleTextSize} {
+		if !rules[rule] {
+			t.Errorf("missing %s issue in %+v", rule, rep.Issues)
+		}
+	}
+	if rep.Err() == nil {
+		t.Error("have nil error for failed audit")
+	}
+
+	btn.Hide()
+	if rep := env.Audit(); len(rep.Issues) != 0 {
+		t.Errorf("hidden sheet audited: %+v", rep.Issues)
+	}
+	if b, err := json.Marshal(env.Audit()); err != nil || !strings.HasSuffix(string(b), `"issues":[]}`) {
+		t.Errorf("have %s, %v", b, err)
+	}
+}
+
+func TestAuditSheetOrder(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	env.NewMaterial(nil)
+	btn := env.NewButton(nil)
+	btn.world[0][0], btn.world[1][1] = 32, 32
+	env.sheets[0].M().world[2][3] = 4
+
+	sort.Sort(byZ(env.sheets)) // as Draw does
+	rep := env.Audit()
+	if len(rep.Issues) == 0 || rep.Issues[0].Sheet != 1 {
+		t.Errorf("have issues %+v, want sheet 1 as added", rep.Issues)
+	}
+}
+
+func TestAuditOffsetBounds(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	card := env.NewMaterial(nil)
+	card.BehaviorFlags = DescriptorRaised
+	card.SetColor(Black)
+	card.world[1][3] = 100
+	card.world[0][0], card.world[1][1] = 100, 100
+	btn := env.NewButton(nil)
+	btn.BehaviorFlags = DescriptorFlat
+	btn.world[0][0], btn.world[1][1] = 32, 32
+	btn.offset[1] = 120 // as lifted above a snackbar
+
+	rep := env.Audit()
+	if len(rep.Issues) == 0 || rep.Issues[0].Bounds[1] != 120 {
+		t.Errorf("have issues %+v, want bounds at y 120", rep.Issues)
+	}
+	if c := env.backdrop(btn.Material, env.Theme()); c != card.background(env.Theme()) {
+		t.Errorf("have backdrop %v, want fill of card beneath offset button", c)
+	}
+	btn.SetText("ok")
+	root := env.AccessibilityTree()
+	if len(root.Children) != 1 || root.Children[0].Bounds[1] != 120 {
+		t.Errorf("have nodes %+v, want button at y 120", root.Children)
+	}
+}
@@ -0,0 +1,117 @@
+package material
+
+import "golang.org/x/mobile/event/size"
+
+// WindowClass groups breakpoints by window size.
+type WindowClass int
+
+const (
+	WindowXSmall WindowClass = iota
+	WindowSmall
+	WindowMedium
+	WindowLarge
+	WindowXLarge
+)
+
+// Device is the class of device a breakpoint is typical of.
+type Device int
+
+const (
+	DeviceHandset Device = iota
+	DeviceTablet
+	DeviceDesktop
+)
+
+// Breakpoint describes the layout grid of windows at least Width wide.
+type Breakpoint struct {
+	Width Dp
+	Class WindowClass
+
+	// Device typical of breakpoint for each orientation.
+	Portrait, Landscape Device
+
+	Columns int
+	Margin  Dp
+	Gutter  Dp
+}
+
+// Device returns device typical of breakpoint for orientation o. If o is unknown,
+// orientation is determined from current window size.
+func (bp Breakpoint) Device(o size.Orientation) Device {
+	if o == size.OrientationUnknown {
+		o = size.OrientationPortrait
+		if windowSize.WidthPx > windowSize.HeightPx {
+			o = size.OrientationLandscape
+		}
+	}
+	if o == size.OrientationLandscape {
+		return bp.Landscape
+	}
+	return bp.Portrait
+}
+
+// Breakpoints is a table of breakpoints sorted by ascending width.
+type Breakpoints []Breakpoint
+
+// DefaultBreakpoints is the breakpoint system defined by the material spec.
+// https://material.io/guidelines/layout/responsive-ui.html#responsive-ui-breakpoints
+var DefaultBreakpoints = Breakpoints{
+	{0, WindowXSmall, DeviceHandset, DeviceHandset, 4, 16, 16},
+	{360, WindowXSmall, DeviceHandset, DeviceHandset, 4, 16, 16},
+	{400, WindowXSmall, DeviceHandset, DeviceHandset, 4, 16, 16},
+	{480, WindowXSmall, DeviceHandset, DeviceHandset, 4, 16, 16},
+	{600, WindowSmall, DeviceTablet, DeviceHandset, 8, 16, 16},
+	{720, WindowSmall, DeviceTablet, DeviceHandset, 8, 24, 24},
+	{840, WindowSmall, DeviceTablet, DeviceTablet, 12, 24, 24},
+	{960, WindowSmall, DeviceDesktop, DeviceTablet, 12, 24, 24},
+	{1024, WindowMedium, DeviceDesktop, DeviceTablet, 12, 24, 24},
+	{1280, WindowMedium, DeviceDesktop, DeviceTablet, 12, 24, 24},
+	{1440, WindowLarge, DeviceDesktop, DeviceDesktop, 12, 24, 24},
+	{1600, WindowLarge, DeviceDesktop, DeviceDesktop, 12, 24, 24},
+	{1920, WindowXLarge, DeviceDesktop, DeviceDesktop, 12, 24, 24},
+}
+
+// Find returns the widest breakpoint not exceeding width in pixels. If no
+// breakpoint matches, the first breakpoint is returned.
+func (bps Breakpoints) Find(width int) Breakpoint {
+	if len(bps) == 0 {
+		return DefaultBreakpoints.Find(width)
+	}
+	bp := bps[0]
+	for _, x := range bps[1:] {
+		if width < int(x.Width.Px()) {
+			break
+		}
+		bp = x
+	}
+	return bp
+}
+
+// Grid returns layout grid for current window size. Breakpoints with fewer
+// than one column are given one.
+func (bps Breakpoints) Grid() *Grid {
+	bp := bps.Find(windowSize.WidthPx)
+	cols := bp.Columns
+	if cols < 1 {
+		cols = 1
+	}
+	return &Grid{
+		Margin:     bp.Margin.Px(),
+		Gutter:     bp.Gutter.Px(),
+		Columns:    cols,
+		Breakpoint: bp,
+	}
+}
+
+// setGrid updates env.Grid from env.Breakpoints for current window size and
+// calls env.OnBreakpoint if the active breakpoint changed.
+func (env *Environment) setGrid() {
+	var prev Breakpoint
+	if env.Grid != nil {
+		prev = env.Grid.Breakpoint
+	}
+	env.Grid = env.Breakpoints.Grid()
+	if env.OnBreakpoint != nil && prev != env.Grid.Breakpoint {
+		env.OnBreakpoint(prev, env.Grid.Breakpoint)
+	}
+}
@@ -0,0 +1,71 @@
+package material
+
+import (
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+)
+
+func TestBreakpointsFind(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+
+	tests := []struct {
+		width   int
+		bp      Dp
+		columns int
+	}{
+		{0, 0, 4},
+		{359, 0, 4},
+		{360, 360, 4},
+		{599, 480, 4},
+		{600, 600, 8},
+		{839, 720, 8},
+		{840, 840, 12},
+		{1600, 1600, 12},
+		{4000, 1920, 12},
+	}
+	for _, tt := range tests {
+		bp := DefaultBreakpoints.Find(tt.width)
+		if bp.Width != tt.bp || bp.Columns != tt.columns {
+			t.Errorf("width %v: have breakpoint %v with %v columns, want %v with %v", tt.width, bp.Width, bp.Columns, tt.bp, tt.columns)
+		}
+	}
+}
+
+func TestEnvironmentOnBreakpoint(t *testing.T) {
+	env := new(Environment)
+	env.Breakpoints = Breakpoints{
+		{Width: 0, Columns: 4, Margin: 16, Gutter: 16},
+		{Width: 500, Columns: 8, Margin: 24, Gutter: 24},
+	}
+
+	var calls int
+	env.OnBreakpoint = func(prev, next Breakpoint) {
+		if calls == 0 && prev != (Breakpoint{}) {
+			t.Errorf("have prev %+v on first layout, want zero", prev)
+		}
+		calls++
+	}
+
+	for _, w := range []int{320, 400, 600, 700, 320} {
+		windowSize = size.Event{WidthPx: w, HeightPx: 800, PixelsPerPt: 1}
+		env.setGrid()
+	}
+	if calls != 3 {
+		t.Errorf("have %v calls, want 3", calls)
+	}
+	if env.Grid.Columns != 4 || env.Grid.Margin != 16 {
+		t.Errorf("have %v columns with %v margin, want 4 with 16", env.Grid.Columns, env.Grid.Margin)
+	}
+}
+
+func TestBreakpointsGridColumns(t *testing.T) {
+	windowSize = size.Event{WidthPx: 320, PixelsPerPt: 1}
+	gd := Breakpoints{{Width: 0, Margin: 16}}.Grid()
+	if gd.Columns != 1 {
+		t.Fatalf("have %v columns, want 1", gd.Columns)
+	}
+	if w := gd.ColumnWidth(); w != 320-2*16 {
+		t.Errorf("have column width %v, want %v", w, 320-2*16)
+	}
+}
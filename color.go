@@ -1,5 +1,13 @@
 package material
 
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+)
+
+// Color is a non-alpha-premultiplied sRGB color packed as 0xRRGGBBAA.
 type Color uint32
 
 // RGBA returns the unit value of each component.
@@ -12,6 +20,7 @@ func (c Color) RGBA() (r, g, b, a float32) {
 	return
 }
 
+// RGBA64 returns the unit value of each component as float64.
 func (c Color) RGBA64() (r, g, b, a float64) {
 	ur := uint8(c >> 24)
 	ug := uint8(c >> 16)
@@ -21,8 +30,237 @@ func (c Color) RGBA64() (r, g, b, a float64) {
 	return
 }
 
+// WithAlpha returns c with alpha component a.
 func (c Color) WithAlpha(a uint8) Color {
-	return c ^ Color(255-a)
+	return c&^0xFF | Color(a)
+}
+
+// Alpha returns alpha component of c.
+func (c Color) Alpha() uint8 { return uint8(c) }
+
+// String returns c formatted as #RRGGBBAA.
+func (c Color) String() string {
+	return fmt.Sprintf("#%08X", uint32(c))
+}
+
+// ParseColor parses color formatted as #RRGGBB or #RRGGBBAA. Colors without
+// alpha are opaque.
+func ParseColor(s string) (Color, error) {
+	if len(s) == 0 || s[0] != '#' || (len(s) != 7 && len(s) != 9) {
+		return 0, fmt.Errorf("material: invalid color %q", s)
+	}
+	x, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("material: invalid color %q", s)
+	}
+	if len(s) == 7 {
+		x = x<<8 | 0xFF
+	}
+	return Color(x), nil
+}
+
+// NRGBA returns c as an image/color value.
+func (c Color) NRGBA() color.NRGBA {
+	return color.NRGBA{uint8(c >> 24), uint8(c >> 16), uint8(c >> 8), uint8(c)}
+}
+
+// ColorOf returns c converted to Color.
+func ColorOf(c color.Color) Color {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return Color(n.R)<<24 | Color(n.G)<<16 | Color(n.B)<<8 | Color(n.A)
+}
+
+// unitColor returns color of unit components r, g, b, a.
+func unitColor(r, g, b, a float64) Color {
+	u := func(x float64) Color {
+		switch {
+		case x <= 0:
+			return 0
+		case x >= 1:
+			return 255
+		}
+		return Color(x*255 + 0.5)
+	}
+	return u(r)<<24 | u(g)<<16 | u(b)<<8 | u(a)
+}
+
+// Linear returns components of c in linear light; alpha is unchanged.
+func (c Color) Linear() (r, g, b, a float64) {
+	r, g, b, a = c.RGBA64()
+	return linear(r), linear(g), linear(b), a
+}
+
+// FromLinear returns color of components in linear light.
+func FromLinear(r, g, b, a float64) Color {
+	return unitColor(srgb(r), srgb(g), srgb(b), a)
+}
+
+// HSL returns hue in degrees [0..360), saturation and lightness in range
+// [0..1] of c.
+func (c Color) HSL() (h, s, l float64) {
+	r, g, b, _ := c.RGBA64()
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	h, l = hue(r, g, b, max, min), (max+min)/2
+	if d := max - min; d != 0 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+	return h, s, l
+}
+
+// HSL returns opaque color of hue in degrees, saturation and lightness in
+// range [0..1].
+func HSL(h, s, l float64) Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	return fromHue(h, c, l-c/2)
+}
+
+// HSV returns hue in degrees [0..360), saturation and value in range [0..1]
+// of c.
+func (c Color) HSV() (h, s, v float64) {
+	r, g, b, _ := c.RGBA64()
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	h, v = hue(r, g, b, max, min), max
+	if max != 0 {
+		s = (max - min) / max
+	}
+	return h, s, v
+}
+
+// HSV returns opaque color of hue in degrees, saturation and value in range
+// [0..1].
+func HSV(h, s, v float64) Color {
+	c := v * s
+	return fromHue(h, c, v-c)
+}
+
+// hue returns hue in degrees of r, g, b with max and min of components.
+func hue(r, g, b, max, min float64) float64 {
+	d := max - min
+	var h float64
+	switch {
+	case d == 0:
+		return 0
+	case max == r:
+		h = math.Mod((g-b)/d, 6)
+	case max == g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// fromHue returns opaque color of hue in degrees with chroma c and m added
+// to each component.
+func fromHue(h, c, m float64) Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = c, x
+	case h < 120:
+		r, g = x, c
+	case h < 180:
+		g, b = c, x
+	case h < 240:
+		g, b = x, c
+	case h < 300:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	return unitColor(r+m, g+m, b+m, 1)
+}
+
+// Lighten returns c with HSL lightness increased by amount in range [0..1].
+func (c Color) Lighten(amount float64) Color {
+	h, s, l := c.HSL()
+	return HSL(h, s, math.Min(1, l+amount)).WithAlpha(c.Alpha())
+}
+
+// Darken returns c with HSL lightness decreased by amount in range [0..1].
+func (c Color) Darken(amount float64) Color {
+	h, s, l := c.HSL()
+	return HSL(h, s, math.Max(0, l-amount)).WithAlpha(c.Alpha())
+}
+
+// Mix returns blend of a and b in linear light, where t of zero is a and t of
+// one is b. Alpha is interpolated linearly.
+func Mix(a, b Color, t float64) Color {
+	ar, ag, ab, aa := a.Linear()
+	br, bg, bb, ba := b.Linear()
+	return FromLinear(ar+(br-ar)*t, ag+(bg-ag)*t, ab+(bb-ab)*t, aa+(ba-aa)*t)
+}
+
+// Over returns c composited over bg.
+func (c Color) Over(bg Color) Color {
+	sr, sg, sb, sa := c.RGBA64()
+	dr, dg, db, da := bg.RGBA64()
+	a := sa + da*(1-sa)
+	if a == 0 {
+		return 0
+	}
+	f := func(s, d float64) float64 { return (s*sa + d*da*(1-sa)) / a }
+	return unitColor(f(sr, dr), f(sg, dg), f(sb, db), a)
+}
+
+// colorOf returns color of unit components r, g, b, a.
+func colorOf(r, g, b, a float32) Color {
+	return unitColor(float64(r), float64(g), float64(b), float64(a))
+}
+
+// Luminance returns relative luminance of c in range [0..1], ignoring alpha.
+// https://www.w3.org/TR/WCAG20/#relativeluminancedef
+func (c Color) Luminance() float64 {
+	r, g, b, _ := c.Linear()
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// Contrast returns contrast ratio of a and b in range [1..21].
+// https://www.w3.org/TR/WCAG20/#contrast-ratiodef
+func Contrast(a, b Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// OnColor returns black or white, whichever contrasts more with bg, for text
+// and icons drawn over bg.
+func OnColor(bg Color) Color {
+	if Contrast(Black, bg) >= Contrast(White, bg) {
+		return Black
+	}
+	return White
+}
+
+// toLinear converts sRGB encoded unit value to linear light.
+func toLinear(x float32) float32 { return float32(linear(float64(x))) }
+
+// fromLinear converts linear light unit value to sRGB encoding.
+func fromLinear(x float32) float32 { return float32(srgb(float64(x))) }
+
+func linear(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+func srgb(x float64) float64 {
+	if x <= 0.0031308 {
+		return x * 12.92
+	}
+	return 1.055*math.Pow(x, 1/2.4) - 0.055
 }
 
 const (
@@ -0,0 +1,83 @@
+package material
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestColorWithAlpha(t *testing.T) {
+	if c := Red500.WithAlpha(0x80); c != 0xF4433680 {
+		t.Errorf("have %v, want #F4433680", c)
+	}
+	if c := Color(0xF4433640).WithAlpha(0x80); c != 0xF4433680 {
+		t.Errorf("have %v for translucent color, want #F4433680", c)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Color
+		err  bool
+	}{
+		{"#F44336", Red500, false},
+		{"#f4433680", 0xF4433680, false},
+		{"F44336", 0, true},
+		{"#F4433", 0, true},
+		{"#GG4336", 0, true},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.s)
+		if (err != nil) != tt.err || c != tt.want {
+			t.Errorf("ParseColor(%q): have %v, %v; want %v", tt.s, c, err, tt.want)
+		}
+	}
+	if s := Red500.String(); s != "#F44336FF" {
+		t.Errorf("have %q, want #F44336FF", s)
+	}
+}
+
+func TestColorConversions(t *testing.T) {
+	if c := ColorOf(Red500.NRGBA()); c != Red500 {
+		t.Errorf("image/color round trip: have %v, want %v", c, Red500)
+	}
+	if c := ColorOf(color.Gray{0x80}); c != 0x808080FF {
+		t.Errorf("have %v, want #808080FF", c)
+	}
+
+	for _, c := range []Color{Red500, Indigo500, Yellow500, BlueGrey900, White, Black} {
+		if x := HSL(c.HSL()); x != c {
+			t.Errorf("HSL round trip: have %v, want %v", x, c)
+		}
+		if x := HSV(c.HSV()); x != c {
+			t.Errorf("HSV round trip: have %v, want %v", x, c)
+		}
+		if x := FromLinear(c.Linear()); x != c {
+			t.Errorf("linear round trip: have %v, want %v", x, c)
+		}
+	}
+
+	h, s, l := Color(0x00FF00FF).HSL()
+	if h != 120 || s != 1 || l != 0.5 {
+		t.Errorf("have hsl %v %v %v, want 120 1 0.5", h, s, l)
+	}
+}
+
+func TestColorBlend(t *testing.T) {
+	if c := Mix(Black, White, 0.5); c != 0xBCBCBCFF {
+		t.Errorf("have %v, want #BCBCBCFF", c)
+	}
+	if c := Black.WithAlpha(0x80).Over(White); c != 0x7F7F7FFF {
+		t.Errorf("have %v, want #7F7F7FFF", c)
+	}
+	if c := Color(0).Over(Red500); c != Red500 {
+		t.Errorf("have %v, want %v", c, Red500)
+	}
+	if _, _, l := Red500.Lighten(0.1).HSL(); math.Abs(l-0.684) > 0.005 {
+		t.Errorf("have lightness %v, want ~0.684", l)
+	}
+	if c := Red500.WithAlpha(0x40).Darken(1); c != 0x00000040 {
+		t.Errorf("have %v, want #00000040", c)
+	}
+}
@@ -1,6 +1,7 @@
 package material
 
 import (
+	"fmt"
 	"log"
 	"path/filepath"
 	"strings"
@@ -46,3 +47,103 @@ func watchShaders() (chan string, chan bool) {
 
 	return event, quit
 }
+
+// DebugFlags selects debug overlays drawn by Environment.
+type DebugFlags int
+
+const (
+	// DebugGrid draws margins, gutters, columns and keylines of layout grid.
+	DebugGrid DebugFlags = 1 << iota
+
+	// DebugBoxes outlines every visible solved box and labels it with z value.
+	DebugBoxes
+)
+
+var (
+	DebugMarginColor  = Color(0x03A9F499)
+	DebugColumnColor  = Color(0x03A9F433)
+	DebugKeylineColor = Color(0xE91E63FF)
+	DebugBoxColor     = Color(0x4CAF50FF)
+)
+
+// Keylines returns horizontal offsets, in pixels, of keylines for grid; the
+// left edge margin, the content left margin and the right edge margin.
+func (gd *Grid) Keylines() []float32 {
+	content := Dp(72).Px()
+	if gd.Breakpoint.Width >= 600 {
+		content = Dp(80).Px()
+	}
+	return []float32{gd.Margin, content, float32(windowSize.WidthPx) - gd.Margin}
+}
+
+// appendDebug appends overlays selected by flags to dst above all sheets.
+// Overlay materials are pooled and only valid until the next call.
+func (env *Environment) appendDebug(dst []Sheet, flags DebugFlags) []Sheet {
+	var z float32
+	for _, sheet := range env.sheets {
+		if sz := float32(sheet.M().Elevation()); sz > z {
+			z = sz
+		}
+	}
+	z++
+
+	n := 0
+	rect := func(x, y, w, h float32, color Color) *Material {
+		if n == len(env.debug) {
+			m := New(nil, color)
+			m.overlay = true
+			env.debug = append(env.debug, m)
+		}
+		m := env.debug[n]
+		n++
+		m.SetColor(color)
+		m.SetText("")
+		m.world.Identity()
+		m.world[0][3], m.world[1][3], m.world[2][3] = x, y, z
+		m.world[0][0], m.world[1][1] = w, h
+		dst = append(dst, m)
+		return m
+	}
+
+	width, height := float32(windowSize.WidthPx), float32(windowSize.HeightPx)
+	line := Dp(1).Px()
+
+	if flags&DebugGrid != 0 && env.Grid != nil {
+		gd := env.Grid
+		rect(0, 0, gd.Margin, height, DebugMarginColor)
+		rect(width-gd.Margin, 0, gd.Margin, height, DebugMarginColor)
+		cw := gd.ColumnWidth()
+		for i := 0; i < gd.Columns; i++ {
+			x := gd.ColumnStart(i)
+			rect(x, 0, cw, height, DebugColumnColor)
+			if i != 0 {
+				rect(x-gd.Gutter, 0, gd.Gutter, height, DebugMarginColor)
+			}
+		}
+		for _, x := range gd.Keylines() {
+			rect(x-line/2, 0, line, height, DebugKeylineColor)
+		}
+	}
+
+	if flags&DebugBoxes != 0 {
+		for _, sheet := range env.sheets {
+			if sheet.Hidden() {
+				continue
+			}
+			m := sheet.M()
+			x, y, w, h := m.world[0][3], m.world[1][3], m.world[0][0], m.world[1][1]
+			rect(x, y, w, line, DebugBoxColor)
+			rect(x, y+h-line, w, line, DebugBoxColor)
+			rect(x, y, line, h, DebugBoxColor)
+			rect(x+w-line, y, line, h, DebugBoxColor)
+
+			th := Dp(12).Px()
+			label := rect(x+line, y+h-th-line, w, th, DebugBoxColor.WithAlpha(0))
+			label.SetTextColor(DebugBoxColor)
+			label.SetTextHeight(th)
+			label.SetText(fmt.Sprintf("z %v", m.Elevation()))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,359 @@
+package material
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dskinner/material/text"
+	"github.com/dskinner/simplex"
+)
+
+// DialogKind determines layout of a dialog and how it's dismissed.
+// https://material.io/guidelines/components/dialogs.html
+type DialogKind int
+
+const (
+	// DialogAlert interrupts with urgent information and is dismissed only by
+	// choosing one of its actions.
+	DialogAlert DialogKind = iota
+
+	// DialogSimple lists items acted on when touched; touching the scrim
+	// dismisses it.
+	DialogSimple
+
+	// DialogConfirmation lists options selected with radio buttons before
+	// choosing one of its actions; touching the scrim dismisses it.
+	DialogConfirmation
+
+	// DialogFullScreen fills the window, with a close button, title and
+	// actions in a bar along its top.
+	DialogFullScreen
+)
+
+// DialogDismissed is the action of dialogs dismissed without choosing one.
+const DialogDismissed = ""
+
+// DialogDur is duration of dialogs fading in; dialogs fade out in half the time.
+var DialogDur = 150 * time.Millisecond
+
+// Dialog is a modal surface centered on the window above a scrim. While
+// visible, touches to sheets beneath are blocked.
+//
+// Labels of actions and items are their text, or Label if set.
+type Dialog struct {
+	*Material
+	Kind DialogKind
+
+	// OnAction is called with label of action or item chosen, or with
+	// DialogDismissed, as dialog is hidden.
+	OnAction func(action string)
+
+	Title, Body *Material
+
+	close   *Button // full-screen dialogs only
+	items   []*Button
+	options []*RadioButton
+	labels  []*Material // of options
+	group   RadioGroup
+	actions []*Button
+
+	closing bool // fading out
+	anim    *Handle
+}
+
+// AddAction adds btn as a text button choosing action labeled by btn.
+func (dlg *Dialog) AddAction(btn *Button) {
+	btn.BehaviorFlags = DescriptorFlat
+	btn.textButton = true
+	btn.text.middle = true
+	btn.owner = dlg.Material
+	btn.restyle()
+	btn.OnPress = func() { dlg.choose(label(btn.Material)) }
+	dlg.actions = append(dlg.actions, btn)
+}
+
+// AddItem adds btn as a row of simple dialog choosing action labeled by btn.
+func (dlg *Dialog) AddItem(btn *Button) {
+	btn.BehaviorFlags = DescriptorFlat
+	btn.text.middle = true
+	btn.owner = dlg.Material
+	btn.restyle()
+	btn.OnPress = func() { dlg.choose(label(btn.Material)) }
+	dlg.items = append(dlg.items, btn)
+	dlg.ApplyTheme(dlg.theme())
+}
+
+// AddOption adds rb as a row of confirmation dialog labeled by rb.Label, moving
+// rb to the radio group of dialog.
+func (dlg *Dialog) AddOption(rb *RadioButton) {
+	if grp := rb.grp; grp != nil {
+		for i, x := range grp.buttons {
+			if x == rb {
+				grp.buttons = append(grp.buttons[:i], grp.buttons[i+1:]...)
+				break
+			}
+		}
+	}
+	rb.grp = &dlg.group
+	dlg.group.buttons = append(dlg.group.buttons, rb)
+	rb.owner = dlg.Material
+
+	l := dlg.env.newPart(nil, dlg.Material)
+	l.text.middle = true
+	l.SetText(rb.Label)
+	dlg.options = append(dlg.options, rb)
+	dlg.labels = append(dlg.labels, l)
+	dlg.ApplyTheme(dlg.theme())
+}
+
+// Selected returns label of selected option of confirmation dialog, or
+// DialogDismissed if none is selected.
+func (dlg *Dialog) Selected() string {
+	if rb := dlg.group.Selected(); rb != nil {
+		return rb.Label
+	}
+	return DialogDismissed
+}
+
+// label returns Label of material, or its text if not set.
+func label(m *Material) string {
+	if m.Label != "" {
+		return m.Label
+	}
+	return m.text.value
+}
+
+// dismissible reports whether dialog is dismissed by touching the scrim.
+func (dlg *Dialog) dismissible() bool {
+	return dlg.Kind == DialogSimple || dlg.Kind == DialogConfirmation
+}
+
+// choose hides dialog and calls OnAction with action.
+func (dlg *Dialog) choose(action string) {
+	if dlg.closing || dlg.Hidden() {
+		return
+	}
+	dlg.Hide()
+	if dlg.OnAction != nil {
+		dlg.OnAction(action)
+	}
+}
+
+// Show fades dialog and scrim in.
+func (dlg *Dialog) Show() {
+	if !dlg.hidden && !dlg.closing {
+		return
+	}
+	if dlg.hidden {
+		dlg.fade = 1
+	}
+	dlg.hidden, dlg.closing = false, false
+	dlg.fadeTo(0, DialogDur, Decelerate, nil)
+}
+
+// Hide fades dialog and scrim out. Dialog is drawn until faded and OnAction
+// is not called.
+func (dlg *Dialog) Hide() {
+	if dlg.hidden || dlg.closing {
+		return
+	}
+	dlg.closing = true
+	dlg.fadeTo(1, DialogDur/2, Linear, func() {
+		if dlg.closing {
+			dlg.hidden, dlg.closing = true, false
+		}
+	})
+}
+
+func (dlg *Dialog) fadeTo(to float32, dur time.Duration, sig Curve, end func()) {
+	if dlg.anim != nil {
+		dlg.anim.Cancel()
+	}
+	from := dlg.fade
+	dlg.anim = dlg.env.Animate(Animation{
+		Sig: sig,
+		Dur: dur,
+		Start: func() {
+			from = dlg.fade
+		},
+		Interp: func(dt float32) {
+			dlg.fade = from + (to-from)*dt
+		},
+		End: end,
+	})
+}
+
+// textWidth returns width of single line s drawn h high.
+func textWidth(s string, h float32) (w float32) {
+	for _, r := range s {
+		w += text.Bounds[r][4] * h
+	}
+	return w
+}
+
+// fitText returns width of text button fitting its label with 8dp padding,
+// at least 64dp, and insets its label to center it.
+func (btn *Button) fitText() float32 {
+	tw := textWidth(label(btn.Material), btn.text.height)
+	w := tw + Dp(16).Px()
+	if min := Dp(64).Px(); w < min {
+		w = min
+	}
+	btn.text.inset = (w - tw) / 2
+	return w
+}
+
+// below returns constraint placing top of a at y below top of b.
+func below(a, b Box, y float32) simplex.Constraint {
+	return simplex.Constrain(simplex.Coef{1, b.t}, simplex.Coef{-1, a.t}).Equal(float64(y))
+}
+
+// inset returns constraints placing start of a by start from start of b, and
+// end of a by end from end of b.
+func inset(a, b Box, start, end float32) []simplex.Constraint {
+	return []simplex.Constraint{
+		simplex.Constrain(simplex.Coef{1, a.l}, simplex.Coef{-1, b.l}).Equal(float64(start)),
+		simplex.Constrain(simplex.Coef{1, b.r}, simplex.Coef{-1, a.r}).Equal(float64(end)),
+	}
+}
+
+// Constraints centers dialog on the window, sized to fit its content, or fills
+// the window if full-screen.
+func (dlg *Dialog) Constraints(env *Environment) []simplex.Constraint {
+	var (
+		th     = dlg.theme()
+		pad    = Dp(24).Px()
+		ww, wh = float32(windowSize.WidthPx), float32(windowSize.HeightPx)
+		y      float32 // from top of dialog
+		cns    []simplex.Constraint
+	)
+	place := func(m *Material, y, h float32) {
+		cns = append(cns, below(m.Box, dlg.Box, y), m.Height(h), m.ElevateAbove(dlg.Box, partZ))
+	}
+	hidden := func(m *Material) {
+		// collapse unused parts so they're neither drawn nor touched.
+		cns = append(cns, m.Width(0), m.Height(0), m.Start(0), m.Bottom(0), m.ElevateAbove(dlg.Box, partZ))
+	}
+
+	// actions are placed from end of dialog, or of bar of full-screen dialog.
+	actions := func(y float32) {
+		end := Dp(8).Px()
+		for _, btn := range dlg.actions {
+			w := btn.fitText()
+			place(btn.Material, y, Dp(36).Px())
+			cns = append(cns, btn.Width(w), simplex.Constrain(simplex.Coef{1, dlg.r}, simplex.Coef{-1, btn.r}).Equal(float64(end)))
+			end += w + Dp(8).Px()
+		}
+	}
+
+	if dlg.Kind == DialogFullScreen {
+		bar := Dp(56).Px()
+		cns = append(cns, dlg.Start(0), dlg.Bottom(0), dlg.Width(ww), dlg.Height(wh), dlg.Elevate(ElevationDialog))
+		s := Dp(24).Px()
+		place(dlg.close.Material, (bar-s)/2, s)
+		cns = append(cns, dlg.close.Width(s), dlg.close.StartIn(dlg.Box, Dp(16).Px()))
+		place(dlg.Title, (bar-th.Type.H6.Px())/2, th.Type.H6.Px())
+		cns = append(cns, inset(dlg.Title.Box, dlg.Box, Dp(72).Px(), Dp(16).Px())...)
+		actions((bar - Dp(36).Px()) / 2)
+		y = bar + pad
+	} else {
+		w := Dp(560).Px()
+		if max := ww - 2*Dp(48).Px(); w > max {
+			w = max
+		}
+		if min := Dp(280).Px(); w < min {
+			w = min
+			if max := ww - 2*Dp(16).Px(); w > max {
+				w = max
+			}
+		}
+		cns = append(cns, dlg.Width(w), dlg.CenterHorizontalIn(env.Box), dlg.CenterVerticalIn(env.Box), dlg.Elevate(ElevationDialog))
+		y = pad
+		if dlg.Title.text.value != "" {
+			place(dlg.Title, y, dlg.Title.text.height)
+			cns = append(cns, inset(dlg.Title.Box, dlg.Box, pad, pad)...)
+			y += dlg.Title.text.height + Dp(20).Px()
+		} else {
+			hidden(dlg.Title)
+		}
+	}
+
+	if body := dlg.Body.text.value; body != "" {
+		h := dlg.Body.text.height
+		h += float32(strings.Count(body, "\n")) * text.AscentUnit * h
+		place(dlg.Body, y, h)
+		cns = append(cns, inset(dlg.Body.Box, dlg.Box, pad, pad)...)
+		y += h + pad
+	} else {
+		hidden(dlg.Body)
+	}
+
+	row := Dp(48).Px()
+	if len(dlg.items)+len(dlg.options) != 0 {
+		y -= Dp(16).Px() // rows have their own padding
+	}
+	for _, btn := range dlg.items {
+		btn.text.inset = pad
+		place(btn.Material, y, row)
+		cns = append(cns, inset(btn.Box, dlg.Box, 0, 0)...)
+		y += row
+	}
+	for i, rb := range dlg.options {
+		l := dlg.labels[i]
+		l.SetText(rb.Label)
+		place(rb.Material, y, row)
+		place(l, y, row)
+		cns = append(cns, rb.StartIn(dlg.Box, Dp(12).Px()))
+		cns = append(cns, inset(l.Box, dlg.Box, Dp(72).Px(), pad)...)
+		y += row
+	}
+	if len(dlg.items)+len(dlg.options) != 0 {
+		y += Dp(8).Px()
+	}
+
+	if dlg.Kind != DialogFullScreen {
+		if len(dlg.actions) != 0 {
+			actions(y + Dp(8).Px())
+			y += Dp(52).Px()
+		}
+		cns = append(cns, dlg.Height(y))
+	}
+	return cns
+}
+
+// modal returns top-most visible dialog, or nil if none are visible. Dialogs
+// fading out are ignored.
+func (env *Environment) modal() *Dialog {
+	var top *Dialog
+	for _, sheet := range env.sheets {
+		if dlg, ok := sheet.(*Dialog); ok && !dlg.Hidden() && !dlg.closing {
+			if top == nil || dlg.Elevation() >= top.Elevation() {
+				top = dlg
+			}
+		}
+	}
+	return top
+}
+
+// within reports whether m is mtrl or owned by it.
+func within(m, mtrl *Material) bool {
+	for ; m != nil; m = m.owner {
+		if m == mtrl {
+			return true
+		}
+	}
+	return false
+}
+
+// dismissModal reports whether x, y is outside of top-most visible dialog,
+// dismissing the dialog if dismissible.
+func (env *Environment) dismissModal(x, y float32) bool {
+	dlg := env.modal()
+	if dlg == nil || dlg.Contains(x, y) {
+		return false
+	}
+	if dlg.dismissible() {
+		dlg.choose(DialogDismissed)
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package material
+
+import (
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+	"golang.org/x/mobile/event/touch"
+)
+
+// tap touches env at x, y from bottom left of window.
+func tap(env *Environment, x, y float32) {
+	for _, typ := range []touch.Type{touch.TypeBegin, touch.TypeEnd} {
+		env.Touch(touch.Event{X: x, Y: float32(windowSize.HeightPx) - y, Type: typ})
+	}
+}
+
+// newTestDialog returns visible dialog of kind at 100, 100 sized 200 by 100,
+// above a button filling the window.
+func newTestDialog(kind DialogKind) (*Environment, *testClock, *Dialog, *Button) {
+	windowSize = size.Event{PixelsPerPt: 1, WidthPx: 400, HeightPx: 400}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	below := env.NewButton(nil)
+	below.world[0][0], below.world[1][1] = 400, 400
+
+	dlg := env.NewDialog(nil, kind)
+	dlg.world[0][3], dlg.world[1][3] = 100, 100
+	dlg.world[0][0], dlg.world[1][1] = 200, 100
+	dlg.Show()
+	return env, clock, dlg, below
+}
+
+func TestDialogBlocksTouch(t *testing.T) {
+	env, _, dlg, below := newTestDialog(DialogAlert)
+	pressed := false
+	below.OnPress = func() { pressed = true }
+
+	var action *string
+	dlg.OnAction = func(a string) { action = &a }
+
+	tap(env, 150, 150) // dialog
+	tap(env, 20, 20)   // scrim
+	if pressed {
+		t.Errorf("button beneath dialog pressed")
+	}
+	if action != nil || dlg.Hidden() {
+		t.Errorf("alert dismissed by touch")
+	}
+
+	dlg.Hide()
+	tap(env, 20, 20)
+	if !pressed {
+		t.Errorf("button not pressed while dialog fades out")
+	}
+}
+
+func TestDialogDismiss(t *testing.T) {
+	env, clock, dlg, _ := newTestDialog(DialogSimple)
+	var actions []string
+	dlg.OnAction = func(a string) { actions = append(actions, a) }
+
+	tap(env, 20, 20)
+	tap(env, 20, 20)
+	if len(actions) != 1 || actions[0] != DialogDismissed {
+		t.Fatalf("have actions %q, want one dismissed", actions)
+	}
+	if dlg.Hidden() {
+		t.Errorf("dialog hidden before fading out")
+	}
+
+	env.Scheduler().Step()
+	clock.Add(DialogDur)
+	env.Scheduler().Step()
+	if !dlg.Hidden() || dlg.opacity() != 0 {
+		t.Errorf("have hidden %v opacity %v after fading out", dlg.Hidden(), dlg.opacity())
+	}
+}
+
+func TestDialogAction(t *testing.T) {
+	env, _, dlg, _ := newTestDialog(DialogConfirmation)
+	btn := env.NewButton(nil)
+	btn.SetText("OK")
+	dlg.AddAction(btn)
+	btn.world[0][3], btn.world[1][3] = 220, 110
+	btn.world[0][0], btn.world[1][1] = 64, 36
+
+	rb := env.NewRadioButton(nil, nil)
+	rb.Label = "Ringtone"
+	dlg.AddOption(rb)
+	rb.check()
+
+	var action string
+	dlg.OnAction = func(a string) { action = a }
+	tap(env, 250, 120)
+	if action != "OK" || dlg.Selected() != "Ringtone" {
+		t.Errorf("have action %q selected %q, want OK and Ringtone", action, dlg.Selected())
+	}
+	if !btn.textButton || btn.BehaviorFlags&DescriptorFlat == 0 {
+		t.Errorf("action not a flat text button")
+	}
+}
@@ -0,0 +1,110 @@
+package material
+
+import "math"
+
+// CubicBezier is an easing curve defined by control points (X1, Y1) and
+// (X2, Y2) of a cubic bezier from (0, 0) to (1, 1), as in CSS.
+// X1 and X2 must be in range [0..1].
+type CubicBezier struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// Easing curves defined by the material spec.
+// https://material.io/guidelines/motion/duration-easing.html#duration-easing-natural-easing-curves
+var (
+	// Standard is for elements moving between positions on screen.
+	Standard = CubicBezier{0.4, 0, 0.2, 1}
+
+	// Decelerate is for elements entering the screen.
+	Decelerate = CubicBezier{0, 0, 0.2, 1}
+
+	// Accelerate is for elements permanently leaving the screen.
+	Accelerate = CubicBezier{0.4, 0, 1, 1}
+
+	// Sharp is for elements leaving the screen that may return.
+	Sharp = CubicBezier{0.4, 0, 0.6, 1}
+
+	// Linear is for changes in color or opacity.
+	Linear = CubicBezier{0, 0, 1, 1}
+)
+
+// bezier returns coordinate of a cubic bezier from 0 to 1 with control
+// coordinates p1, p2 at parameter s.
+func bezier(p1, p2, s float64) float64 {
+	a, b, c := 1-3*p2+3*p1, 3*p2-6*p1, 3*p1
+	return ((a*s+b)*s + c) * s
+}
+
+// bezierSlope returns derivative of bezier at parameter s.
+func bezierSlope(p1, p2, s float64) float64 {
+	a, b, c := 1-3*p2+3*p1, 3*p2-6*p1, 3*p1
+	return (3*a*s+2*b)*s + c
+}
+
+// At returns y of curve where x is t. Values of t outside [0..1] are clamped.
+func (cb CubicBezier) At(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	if cb.X1 == cb.Y1 && cb.X2 == cb.Y2 {
+		return t
+	}
+	return bezier(cb.Y1, cb.Y2, cb.solve(t))
+}
+
+// solve returns parameter s where x of curve is x. Newton's method is tried
+// first, falling back to bisection where slope is too flat to converge.
+func (cb CubicBezier) solve(x float64) float64 {
+	const epsilon = 1e-7
+
+	s := x
+	for i := 0; i < 8; i++ {
+		d := bezier(cb.X1, cb.X2, s) - x
+		if math.Abs(d) < epsilon {
+			return s
+		}
+		slope := bezierSlope(cb.X1, cb.X2, s)
+		if math.Abs(slope) < 1e-6 {
+			break
+		}
+		s -= d / slope
+	}
+
+	lo, hi := 0.0, 1.0
+	s = x
+	for lo < hi {
+		d := bezier(cb.X1, cb.X2, s) - x
+		if math.Abs(d) < epsilon {
+			break
+		}
+		if d > 0 {
+			hi = s
+		} else {
+			lo = s
+		}
+		if hi-lo < epsilon {
+			break
+		}
+		s = (lo + hi) / 2
+	}
+	return s
+}
+
+type curveFunc func(t float64) float64
+
+func (fn curveFunc) At(t float64) float64 { return fn(t) }
+
+// Mirror returns curve rising from 0 to 1 by c over the first half and falling
+// back to 0 by c over the second half, such as for looping animations that
+// return to rest.
+func Mirror(c Curve) Curve {
+	return curveFunc(func(t float64) float64 {
+		if t < 0.5 {
+			return c.At(2 * t)
+		}
+		return 1 - c.At(2*t-1)
+	})
+}
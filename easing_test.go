@@ -0,0 +1,42 @@
+package material
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCubicBezier(t *testing.T) {
+	for _, cb := range []CubicBezier{Standard, Decelerate, Accelerate, Sharp, Linear} {
+		if y := cb.At(0); y != 0 {
+			t.Errorf("%v: have %v at 0, want 0", cb, y)
+		}
+		if y := cb.At(1); y != 1 {
+			t.Errorf("%v: have %v at 1, want 1", cb, y)
+		}
+		prev := 0.0
+		for i := 1; i < 100; i++ {
+			x := float64(i) / 100
+			y := cb.At(x)
+			if y < prev {
+				t.Errorf("%v: not monotonic at %v", cb, x)
+			}
+			prev = y
+			// x of curve at solved parameter must match input.
+			if d := math.Abs(bezier(cb.X1, cb.X2, cb.solve(x)) - x); d > 1e-6 {
+				t.Errorf("%v: solve %v off by %v", cb, x, d)
+			}
+		}
+	}
+
+	// reference values of cubic-bezier(0.4, 0, 0.2, 1) found by bisection.
+	tests := []struct{ x, y float64 }{
+		{0.25, 0.2366},
+		{0.5, 0.7756},
+		{0.75, 0.9594},
+	}
+	for _, tt := range tests {
+		if y := Standard.At(tt.x); math.Abs(y-tt.y) > 1e-3 {
+			t.Errorf("Standard.At(%v): have %v, want %v", tt.x, y, tt.y)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package material
+
+import (
+	"time"
+
+	"github.com/dskinner/simplex"
+)
+
+// Elevation is the distance in dp of a surface above the background along the
+// z-axis. Elevation determines order of drawing and size of shadows.
+// https://material.io/guidelines/material-design/elevation-shadows.html
+type Elevation float32
+
+// Resting and pressed elevations of components.
+const (
+	ElevationSwitch              Elevation = 1
+	ElevationCard                Elevation = 2
+	ElevationRaisedButton        Elevation = 2
+	ElevationRefresh             Elevation = 3
+	ElevationAppBar              Elevation = 4
+	ElevationFAB                 Elevation = 6
+	ElevationSnackbar            Elevation = 6
+	ElevationMenu                Elevation = 8
+	ElevationBottomNav           Elevation = 8
+	ElevationCardPicked          Elevation = 8
+	ElevationRaisedButtonPressed Elevation = 8
+	ElevationFABPressed          Elevation = 12
+	ElevationNavDrawer           Elevation = 16
+	ElevationBottomSheet         Elevation = 16
+	ElevationDialog              Elevation = 24
+)
+
+// Elevations are the levels at which surfaces rest.
+var Elevations = []Elevation{0, 1, 2, 3, 4, 6, 8, 12, 16, 24}
+
+// ElevationDur is duration of animated changes in elevation.
+var ElevationDur = 150 * time.Millisecond
+
+// Elevate returns constraint setting resting elevation of box to e.
+func (a Box) Elevate(e Elevation) simplex.Constraint {
+	return a.Z(float32(e))
+}
+
+// ElevateAbove returns constraint placing a by dz above b along the z-axis.
+func (a Box) ElevateAbove(b Box, dz float32) simplex.Constraint {
+	return simplex.Constrain(simplex.Coef{1, a.z}, simplex.Coef{-1, b.z}).Equal(float64(dz))
+}
+
+// Elevation returns current elevation of material, including changes from
+// being pressed or from SetElevation.
+func (mtrl *Material) Elevation() Elevation {
+	return Elevation(mtrl.world[2][3] + mtrl.lift)
+}
+
+// SetElevation animates material from its current elevation to e, raising it
+// above its resting elevation by the difference. The difference is kept if
+// layout later changes its resting elevation, until SetElevation is called
+// again. Pressing material temporarily raises it to PressedElevation if
+// greater.
+func (mtrl *Material) SetElevation(e Elevation) {
+	mtrl.raised = float32(e) - mtrl.world[2][3]
+	mtrl.liftTo(mtrl.raised)
+}
+
+// liftTo animates elevation of material above its resting elevation to lift.
+func (mtrl *Material) liftTo(lift float32) {
+	if mtrl.liftAnim != nil {
+		mtrl.liftAnim.Cancel()
+		mtrl.liftAnim = nil
+	}
+	if mtrl.env == nil || ElevationDur <= 0 {
+		mtrl.lift = lift
+		return
+	}
+	from := mtrl.lift
+	mtrl.liftAnim = mtrl.env.Animate(Animation{
+		Sig: Standard,
+		Dur: ElevationDur,
+		Start: func() {
+			from = mtrl.lift
+		},
+		Interp: func(dt float32) {
+			mtrl.lift = from + (lift-from)*dt
+		},
+	})
+}
+
+// pressLift animates material to PressedElevation if pressed, or back to the
+// elevation it had before.
+func (mtrl *Material) pressLift(pressed bool) {
+	if mtrl.BehaviorFlags&DescriptorRaised == 0 || mtrl.PressedElevation == 0 {
+		return
+	}
+	lift := mtrl.raised
+	if p := float32(mtrl.PressedElevation) - mtrl.world[2][3]; pressed && p > lift {
+		lift = p
+	}
+	mtrl.liftTo(lift)
+}
@@ -0,0 +1,46 @@
+package material
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/mobile/event/touch"
+)
+
+func TestMaterialPressedElevation(t *testing.T) {
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	m := New(nil, White)
+	env.add(m)
+	m.world[2][3] = float32(ElevationRaisedButton)
+	m.PressedElevation = ElevationRaisedButtonPressed
+
+	step := func(d time.Duration) {
+		env.Scheduler().Step()
+		clock.Add(d)
+		env.Scheduler().Step()
+	}
+
+	m.press(touch.TypeBegin, 0.5, 0.5, clock.Now())
+	step(ElevationDur)
+	if e := m.Elevation(); e != ElevationRaisedButtonPressed {
+		t.Errorf("have pressed elevation %v, want %v", e, ElevationRaisedButtonPressed)
+	}
+	m.press(touch.TypeEnd, 0.5, 0.5, clock.Now())
+	step(ElevationDur / 2)
+	if e := m.Elevation(); e <= ElevationRaisedButton || e >= ElevationRaisedButtonPressed {
+		t.Errorf("have elevation %v midway, want between resting and pressed", e)
+	}
+	step(ElevationDur)
+	if e := m.Elevation(); e != ElevationRaisedButton {
+		t.Errorf("have released elevation %v, want %v", e, ElevationRaisedButton)
+	}
+
+	m.SetElevation(ElevationCardPicked)
+	step(ElevationDur)
+	m.press(touch.TypeBegin, 0.5, 0.5, clock.Now())
+	step(ElevationDur)
+	if e := m.Elevation(); e != ElevationCardPicked {
+		t.Errorf("have elevation %v, want %v; pressed elevation is lower", e, ElevationCardPicked)
+	}
+}
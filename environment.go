@@ -6,6 +6,7 @@ import (
 	"image"
 	"log"
 	"sort"
+	"sync"
 	"time"
 	"unicode"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/dskinner/material/text"
 	"github.com/dskinner/simplex"
 
+	"golang.org/x/mobile/event/key"
 	"golang.org/x/mobile/event/size"
 	"golang.org/x/mobile/event/touch"
 	"golang.org/x/mobile/exp/f32"
@@ -48,26 +50,83 @@ type Sheet interface {
 	M() *Material
 	Constraints(*Environment) []simplex.Constraint
 	Hidden() bool
+	Show()
+	Hide()
 }
 
 type byZ []Sheet
 
 func (a byZ) Len() int           { return len(a) }
 func (a byZ) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a byZ) Less(i, j int) bool { return a[i].M().world[2][3] < a[j].M().world[2][3] }
-
+func (a byZ) Less(i, j int) bool { return a[i].M().Elevation() < a[j].M().Elevation() }
+
+// Environment manages sheets, their layout, drawing and touch handling.
+//
+// Environment and its sheets are not safe for concurrent use. Draw, Touch,
+// layout methods and setters of sheets must be called from a single goroutine,
+// typically the one receiving app events. Other goroutines, such as network
+// callbacks, update sheets by queueing a function with Do; queued functions
+// run in order at the start of the next Draw, so changes queued together are
+// applied atomically between frames. Animate is safe to call from any goroutine
+// and animations are stepped from Draw.
 type Environment struct {
 	View f32.Mat4
 
 	proj   f32.Mat4
-	plt    Palette
+	theme  *Theme // nil for DefaultTheme
 	sheets []Sheet
 
 	Box  Box
 	Grid *Grid
 
+	// Breakpoints determines Grid for window size. If nil, DefaultBreakpoints is used.
+	Breakpoints Breakpoints
+
+	// OnBreakpoint, if not nil, is called when window size changes active
+	// breakpoint. It's also called on first layout with prev the zero
+	// Breakpoint, so initial layout may be configured in the same place.
+	OnBreakpoint func(prev, next Breakpoint)
+
 	lprg *simplex.Program
 
+	// LayoutDur, if positive, animates sheets from their previous layout to
+	// the layout solved by FinishLayout over the duration, such as on rotation.
+	// While animating, world of each sheet holds the animated value rather
+	// than the solved one and is written by the animation every frame; leave
+	// LayoutDur zero if reading or animating worlds after FinishLayout, such
+	// as with Menu.ShowAt.
+	LayoutDur time.Duration
+	// LayoutSig is the curve of layout animations. If nil, Standard is used.
+	LayoutSig Curve
+
+	prevWorlds []f32.Mat4 // captured by StartLayout when LayoutDur is positive
+	layoutAnim *Handle
+
+	// scrim is drawn beneath the top-most visible temporary surface.
+	scrim      *Material
+	dismissing bool // swallow touch sequence that dismissed a temporary surface
+
+	dragging  dragger     // receives touch events until released
+	snackbars []*Snackbar // queued; first is shown
+
+	// Debug flags toggle debug overlays drawn on top of sheets.
+	Debug DebugFlags
+	debug []*Material // overlay pool
+
+	drawn []Sheet // sheets of current frame
+	added int     // count of sheets added; Draw sorts sheets by z
+
+	names map[string]Sheet // bound for declarative layouts
+
+	// Clock is read by the animation scheduler when first used. If nil,
+	// SystemClock is used.
+	Clock Clock
+	sched *Scheduler
+
+	mu    sync.Mutex // guards sched creation and queue
+	queue []func()   // run at start of next Draw
+	ready []func()
+
 	icons  glutil.Texture
 	glyphs glutil.Texture
 
@@ -83,18 +142,18 @@ type Environment struct {
 
 	attribs struct {
 		vertex, color, dist, texcoord gl.Attrib
-		touch                         gl.Attrib
+		touch, ink                    gl.Attrib
 	}
 
 	buffers struct {
 		verts, colors, dists, texcoords glutil.FloatBuffer
 		indices                         glutil.UintBuffer
-		touches                         glutil.FloatBuffer
+		touches, inks                   glutil.FloatBuffer
 	}
 
 	verts, colors, dists, texcoords []float32
 	indices                         []uint32
-	touches                         []float32
+	touches, inks                   []float32
 
 	watchEvent chan string
 	watchQuit  chan bool
@@ -187,6 +246,7 @@ func (env *Environment) Load(ctx gl.Context) {
 	env.attribs.dist = env.prg.Attrib(ctx, "dist")
 	env.attribs.texcoord = env.prg.Attrib(ctx, "texcoord")
 	env.attribs.touch = env.prg.Attrib(ctx, "touch")
+	env.attribs.ink = env.prg.Attrib(ctx, "ink")
 
 	env.buffers.indices = glutil.NewUintBuffer(ctx, []uint32{}, gl.STREAM_DRAW)
 	env.buffers.verts = glutil.NewFloatBuffer(ctx, []float32{}, gl.STREAM_DRAW)
@@ -194,6 +254,7 @@ func (env *Environment) Load(ctx gl.Context) {
 	env.buffers.dists = glutil.NewFloatBuffer(ctx, []float32{}, gl.STREAM_DRAW)
 	env.buffers.texcoords = glutil.NewFloatBuffer(ctx, []float32{}, gl.STREAM_DRAW)
 	env.buffers.touches = glutil.NewFloatBuffer(ctx, []float32{}, gl.STREAM_DRAW)
+	env.buffers.inks = glutil.NewFloatBuffer(ctx, []float32{}, gl.STREAM_DRAW)
 }
 
 func (env *Environment) Unload(ctx gl.Context) {
@@ -204,6 +265,7 @@ func (env *Environment) Unload(ctx gl.Context) {
 	env.buffers.dists.Delete(ctx)
 	env.buffers.texcoords.Delete(ctx)
 	env.buffers.touches.Delete(ctx)
+	env.buffers.inks.Delete(ctx)
 	if env.glyphs.Value != 0 {
 		env.glyphs.Delete(ctx)
 	}
@@ -211,40 +273,44 @@ func (env *Environment) Unload(ctx gl.Context) {
 		env.icons.Delete(ctx)
 	}
 	env.sheets = env.sheets[:0]
+	env.names = nil
 }
 
 func (env *Environment) SetPerspective(sz size.Event) {
-	windowSize = sz
-	env.Grid = NewGrid()
+	env.setSize(sz)
 	env.View.Identity() // TODO not here, only on creation
 	env.proj.Identity()
 	glutil.Perspective(&env.proj, 0, float32(sz.WidthPx), 0, float32(sz.HeightPx))
 }
 
 func (env *Environment) SetOrtho(sz size.Event) {
-	windowSize = sz
-	env.Grid = NewGrid()
+	env.setSize(sz)
 	env.View.Identity() // TODO not here, only on creation
 	env.proj.Identity()
 	glutil.Ortho(&env.proj, 0, float32(sz.WidthPx), 0, float32(sz.HeightPx), 1, 10000)
 	env.View.Translate(&env.View, 0, 0, -5000)
 }
 
-func (env *Environment) Palette() Palette { return env.plt }
+func (env *Environment) Palette() Palette { return env.Theme().Palette }
 
+// SetPalette sets palette of current theme; see SetTheme.
 func (env *Environment) SetPalette(plt Palette) {
-	env.plt = plt
-	for _, sheet := range env.sheets {
-		switch sheet := sheet.(type) {
-		case *Button:
-			sheet.SetColor(env.plt.Primary)
-		case *Toolbar:
-			sheet.SetColor(env.plt.Light)
+	th := env.Theme()
+	th.Palette = plt
+	env.SetTheme(th)
+}
+
+func (env *Environment) StartLayout() {
+	env.prevWorlds = env.prevWorlds[:0]
+	if env.LayoutDur > 0 {
+		for _, sheet := range env.sheets {
+			env.prevWorlds = append(env.prevWorlds, sheet.M().world)
 		}
 	}
+	env.startLayout()
 }
 
-func (env *Environment) StartLayout() {
+func (env *Environment) startLayout() {
 	env.lprg = new(simplex.Program)
 	env.Box = NewBox(env.lprg)
 	for _, sheet := range env.sheets {
@@ -265,13 +331,89 @@ func (env *Environment) AddConstraints(cns ...simplex.Constraint) {
 	env.lprg.AddConstraints(cns...)
 }
 
+// FinishLayout solves constraints added since StartLayout and updates world
+// of each sheet. If LayoutDur is positive, sheets animate from their previous
+// layout and world holds the animated value; see LayoutDur.
 func (env *Environment) FinishLayout() {
+	env.finishLayout()
+	env.animateLayout()
+}
+
+// finishLayout solves constraints and updates layout state of each sheet; it's
+// shared by FinishLayout and solve so solved layouts match current ones.
+func (env *Environment) finishLayout() {
 	if err := env.lprg.Minimize(); err != nil {
 		log.Println(err)
 	}
 	for _, sheet := range env.sheets {
 		sheet.UpdateWorld(env.lprg)
+		env.collapse(sheet.M())
+	}
+}
+
+// animateLayout restores sheets to worlds captured by StartLayout and animates
+// them to their solved worlds. Sheets without a previous layout, such as on
+// first layout, are not animated.
+func (env *Environment) animateLayout() {
+	if env.LayoutDur <= 0 || len(env.prevWorlds) != len(env.sheets) {
+		return
 	}
+	if env.layoutAnim != nil {
+		env.layoutAnim.Cancel()
+		env.layoutAnim = nil
+	}
+	var anims []Animation
+	for i, sheet := range env.sheets {
+		m, prev := sheet.M(), env.prevWorlds[i]
+		if prev == (f32.Mat4{}) || prev == m.world {
+			continue
+		}
+		w := m.world
+		tw := NewTween(m, env.LayoutDur).Position(w[0][3], w[1][3]).Size(w[0][0], w[1][1]).Z(w[2][3])
+		if env.LayoutSig != nil {
+			tw.Sig = env.LayoutSig
+		}
+		m.world = prev
+		anims = append(anims, tw.Animation())
+	}
+	if len(anims) != 0 {
+		env.layoutAnim = env.Animate(Parallel(anims...))
+	}
+}
+
+// Scheduler returns scheduler stepped by Draw.
+func (env *Environment) Scheduler() *Scheduler {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	if env.sched == nil {
+		env.sched = NewScheduler(env.Clock)
+	}
+	return env.sched
+}
+
+// Do queues fn to run at the start of the next Draw on the drawing goroutine.
+// Do is safe to call from any goroutine.
+func (env *Environment) Do(fn func()) {
+	env.mu.Lock()
+	env.queue = append(env.queue, fn)
+	env.mu.Unlock()
+}
+
+// flush runs functions queued with Do. Functions queued while flushing run
+// on the next flush.
+func (env *Environment) flush() {
+	env.mu.Lock()
+	env.ready, env.queue = env.queue, env.ready[:0]
+	env.mu.Unlock()
+	for i, fn := range env.ready {
+		fn()
+		env.ready[i] = nil
+	}
+}
+
+// Animate schedules anim to be stepped from Draw.
+func (env *Environment) Animate(anim Animation) *Handle {
+	return env.Scheduler().Start(anim)
 }
 
 func (env *Environment) Draw(ctx gl.Context) {
@@ -281,24 +423,47 @@ func (env *Environment) Draw(ctx gl.Context) {
 	default:
 	}
 
+	env.flush()
+	env.Scheduler().Step()
+	env.placeSnackbar()
+	for _, sheet := range env.sheets {
+		if mu, ok := sheet.(*Menu); ok {
+			mu.place() // for current layout
+		}
+	}
+
 	sort.Sort(byZ(env.sheets))
+	env.drawn = env.appendSheets(env.drawn[:0])
+	if env.Debug != 0 {
+		env.drawn = env.appendDebug(env.drawn, env.Debug)
+	}
+	env.draw(ctx, env.drawn)
+}
 
+func (env *Environment) draw(ctx gl.Context, sheets []Sheet) {
 	env.indices = env.indices[:0]
 	env.verts = env.verts[:0]
 	env.colors = env.colors[:0]
 	env.dists = env.dists[:0]
 	env.texcoords = env.texcoords[:0]
 	env.touches = env.touches[:0]
+	env.inks = env.inks[:0]
 
-	for i, sheet := range env.sheets {
+	now, th := env.now(), env.Theme()
+	for _, sheet := range sheets {
+		if sheet.Hidden() {
+			continue
+		}
 		m := sheet.M()
-		x, y, z := m.world[0][3], m.world[1][3], m.world[2][3]
+		x, y := m.at()
+		z := float32(m.Elevation())
 		w, h := m.world[0][0], m.world[1][1]
 		r := m.Roundness
+		op := m.opacity()
 
 		n := uint32(len(env.verts)) / 4
 
-		if i != 0 { // degenerate triangles
+		if len(env.indices) != 0 { // degenerate triangles
 			// TODO make sure last v2 matches based on how shadows are being added
 			env.indices = append(env.indices,
 				env.indices[len(env.indices)-2], env.indices[len(env.indices)-1], env.indices[len(env.indices)-1],
@@ -306,82 +471,14 @@ func (env *Environment) Draw(ctx gl.Context) {
 			)
 		}
 
-		// *** shadow layer
-		if m.BehaviorFlags&DescriptorRaised == DescriptorRaised {
-			// (r/w) should be a value in range [0.0..0.5] given that a value of 0.5
-			// is an ellipse/circle. mapping this range to [1..3]*z provides a decent
-			// default for resizing ellipses shadows for visibility given current
-			// algorithm in shader.
-			// s := (1 + 6*(r/w)) + z // TODO this needs harder limits based on size of material
-			s := 4 + z
-
-			// min := h
-			// if w < h {
-			// min = w
-			// }
-			// _ = min
-
-			// s += min / 32
-
-			ss := 2 * s
-			// TODO how should roundness scale
-			rr := r * ((w + ss) / w)
-			// rr += s
-
-			// rr += min / 8
-
-			// clamp rr for circular shadows
-			if rr > (w+ss)/2 {
-				rr = (w + ss) / 2
-			}
-			// if rr > (h+ss)/2 {
-			// rr = (h + ss) / 2
-			// }
-
-			x -= s
-			w += ss
-
-			y -= s * 1.5 // offset shadow from material
-			h += ss
-
-			env.indices = append(env.indices,
-				n, n+2, n+1,
-				n, n+3, n+2,
-			)
-			env.verts = append(env.verts,
-				x, y, -z, rr, // v0
-				x, y+h, -z, rr, // v1
-				x+w, y+h, -z, rr, // v2
-				x+w, y, -z, rr, // v3
-			)
-			env.colors = append(env.colors,
-				m.cr, m.cg, m.cb, m.ca,
-				m.cr, m.cg, m.cb, m.ca,
-				m.cr, m.cg, m.cb, m.ca,
-				m.cr, m.cg, m.cb, m.ca,
-			)
-			env.dists = append(env.dists,
-				0.0, 0.0, w, h, // v0 left, bottom
-				0.0, 1.0, w, h, // v1 left, top
-				1.0, 1.0, w, h, // v2 right, top
-				1.0, 0.0, w, h, // v3 right, bottom
-			)
-			env.texcoords = append(env.texcoords,
-				-1, -1, -1, -1,
-				-1, -1, -1, -1,
-				-1, -1, -1, -1,
-				-1, -1, -1, -1,
-			)
-			env.touches = append(env.touches,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-			)
+		if m.BehaviorFlags&DescriptorRaised == DescriptorRaised && !m.overlay {
+			// ambient light casts a soft shadow evenly around material, and key
+			// light a sharper shadow offset below material. Spreads are in dp
+			// as elevation is, so shadows scale with density.
+			env.appendShadow(m, x, y, z, w, h, r, Dp(2+z/2).Px(), 0, ambientShadowAlpha*op)
+			env.appendShadow(m, x, y, z, w, h, r, Dp(4+z).Px(), 1.5, keyShadowAlpha*op)
 		}
-		// *** end shadow layer
 
-		x, y, z = m.world[0][3], m.world[1][3], m.world[2][3]
 		w, h = m.world[0][0], m.world[1][1]
 		n = uint32(len(env.verts)) / 4
 
@@ -411,13 +508,14 @@ func (env *Environment) Draw(ctx gl.Context) {
 
 		alpha := float32(0)
 		if m.BehaviorFlags&DescriptorRaised == DescriptorRaised {
-			alpha = m.ca
+			alpha = m.ca * op
 		}
+		cr, cg, cb := m.fill(th)
 		env.colors = append(env.colors,
-			m.cr, m.cg, m.cb, alpha,
-			m.cr, m.cg, m.cb, alpha,
-			m.cr, m.cg, m.cb, alpha,
-			m.cr, m.cg, m.cb, alpha,
+			cr, cg, cb, alpha,
+			cr, cg, cb, alpha,
+			cr, cg, cb, alpha,
+			cr, cg, cb, alpha,
 			1, 1, 1, alpha,
 			1, 1, 1, alpha,
 			1, 1, 1, alpha,
@@ -444,19 +542,46 @@ func (env *Environment) Draw(ctx gl.Context) {
 			-1, -1, -1, -1,
 		)
 
-		ex, ey := m.touch.x, m.touch.y
-		es := float32(m.touch.state)
-		ed := float32(time.Since(m.touch.start) / time.Millisecond)
-		env.touches = append(env.touches,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-			ex, ey, es, ed,
-		)
+		ink := m.inkAt(now, th)
+		if m.Ink.Unbounded {
+			env.appendInk(8, inkLayer{r: ink.r, g: ink.g, b: ink.b, state: ink.state})
+		} else {
+			env.appendInk(8, ink)
+		}
+
+		if m.Ink.Unbounded && ink.alpha > 0 {
+			// ripple drawn as circle centered on material, beyond its bounds.
+			cx, cy, cr := x+ink.x, y+ink.y, ink.radius
+			n = uint32(len(env.verts)) / 4
+			env.indices = append(env.indices,
+				n, n+2, n+1, n, n+3, n+2,
+			)
+			env.verts = append(env.verts,
+				cx-cr, cy-cr, z, cr,
+				cx-cr, cy+cr, z, cr,
+				cx+cr, cy+cr, z, cr,
+				cx+cr, cy-cr, z, cr,
+			)
+			env.colors = append(env.colors,
+				ink.r, ink.g, ink.b, ink.alpha,
+				ink.r, ink.g, ink.b, ink.alpha,
+				ink.r, ink.g, ink.b, ink.alpha,
+				ink.r, ink.g, ink.b, ink.alpha,
+			)
+			env.dists = append(env.dists,
+				0.0, 0.0, 2*cr, 2*cr,
+				0.0, 1.0, 2*cr, 2*cr,
+				1.0, 1.0, 2*cr, 2*cr,
+				1.0, 0.0, 2*cr, 2*cr,
+			)
+			env.texcoords = append(env.texcoords,
+				-1, -1, -1, -1,
+				-1, -1, -1, -1,
+				-1, -1, -1, -1,
+				-1, -1, -1, -1,
+			)
+			env.appendInk(4, inkLayer{})
+		}
 
 		if m.icon.x != -1 {
 			n = uint32(len(env.verts)) / 4
@@ -469,11 +594,13 @@ func (env *Environment) Draw(ctx gl.Context) {
 				x+w, y+h, z, 0,
 				x+w, y, z, 0,
 			)
+			ir, ig, ib, ia := m.iconColor(th)
+			ia *= op
 			env.colors = append(env.colors,
-				m.icon.r, m.icon.g, m.icon.b, m.icon.a,
-				m.icon.r, m.icon.g, m.icon.b, m.icon.a,
-				m.icon.r, m.icon.g, m.icon.b, m.icon.a,
-				m.icon.r, m.icon.g, m.icon.b, m.icon.a,
+				ir, ig, ib, ia,
+				ir, ig, ib, ia,
+				ir, ig, ib, ia,
+				ir, ig, ib, ia,
 			)
 			env.dists = append(env.dists,
 				0.0, 0.0, w, h, // v0 left, bottom
@@ -489,12 +616,7 @@ func (env *Environment) Draw(ctx gl.Context) {
 				ix+s, iy, 1, 0,
 				ix+s, iy+s, 1, 0,
 			)
-			env.touches = append(env.touches,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-				0, 0, 2, 0,
-			)
+			env.appendInk(4, inkLayer{})
 		}
 
 		if m.ShowImage {
@@ -535,37 +657,37 @@ func (env *Environment) Draw(ctx gl.Context) {
 				mX, 0, 3, 0,
 				mX, mY, 3, 0,
 			)
-			env.touches = append(env.touches,
-				0, 0, 3, 0,
-				0, 0, 3, 0,
-				0, 0, 3, 0,
-				0, 0, 3, 0,
-			)
+			env.appendInk(4, inkLayer{})
 		}
 
 		// draw text
-		tx, ty := m.world[0][3], m.world[1][3]
-		th := m.text.height
-		if th == 0 {
-			th = m.world[1][1]
+		tr, tg, tb, ta := m.textColor(th)
+		ta *= op
+		tx, ty := x+m.text.inset, y
+		textH := m.text.height
+		if textH == 0 {
+			textH = m.world[1][1]
 		}
 
-		pad := float32(text.Pad) * (th / text.FontSize)
-		ty = ty + m.world[1][1] - (text.AscentUnit * th)
+		pad := float32(text.Pad) * (textH / text.FontSize)
+		ty = ty + m.world[1][1] - (text.AscentUnit * textH)
+		if m.text.middle {
+			ty -= (m.world[1][1] - textH) / 2
+		}
 
 		for _, r := range m.text.value {
 			a := text.Bounds[r]
 			ax, ay, aw, ah, aa := a[0], a[1], a[2], a[3], a[4]
-			ax *= th
-			ay *= th
-			aw *= th
-			ah *= th
-			aa *= th
+			ax *= textH
+			ay *= textH
+			aw *= textH
+			ah *= textH
+			aa *= textH
 
 			if unicode.IsSpace(r) {
 				if r == '\n' {
-					tx = m.world[0][3]
-					ty -= (text.AscentUnit * th)
+					tx = x + m.text.inset
+					ty -= (text.AscentUnit * textH)
 				}
 			} else {
 				n = uint32(len(env.verts)) / 4
@@ -579,23 +701,18 @@ func (env *Environment) Draw(ctx gl.Context) {
 					tx+ax+aw+pad, ty-ay-pad, z, 0, // v3
 				)
 				env.colors = append(env.colors,
-					m.text.r, m.text.g, m.text.b, m.text.a,
-					m.text.r, m.text.g, m.text.b, m.text.a,
-					m.text.r, m.text.g, m.text.b, m.text.a,
-					m.text.r, m.text.g, m.text.b, m.text.a,
+					tr, tg, tb, ta,
+					tr, tg, tb, ta,
+					tr, tg, tb, ta,
+					tr, tg, tb, ta,
 				)
 				env.dists = append(env.dists,
-					0.0, 0.0, aw, th,
-					0.0, 1.0, aw, th,
-					1.0, 1.0, aw, th,
-					1.0, 0.0, aw, th,
-				)
-				env.touches = append(env.touches,
-					0, 0, 2, 0,
-					0, 0, 2, 0,
-					0, 0, 2, 0,
-					0, 0, 2, 0,
+					0.0, 0.0, aw, textH,
+					0.0, 1.0, aw, textH,
+					1.0, 1.0, aw, textH,
+					1.0, 0.0, aw, textH,
 				)
+				env.appendInk(4, inkLayer{})
 				g := text.Texcoords[r]
 				gx, gy, gw, gh := g[0], g[1], g[2], g[3]
 				env.texcoords = append(env.texcoords,
@@ -624,6 +741,10 @@ func (env *Environment) Draw(ctx gl.Context) {
 	env.buffers.touches.Update(ctx, env.touches)
 	env.prg.Pointer(ctx, env.attribs.touch, 4)
 
+	env.buffers.inks.Bind(ctx)
+	env.buffers.inks.Update(ctx, env.inks)
+	env.prg.Pointer(ctx, env.attribs.ink, 4)
+
 	env.buffers.dists.Bind(ctx)
 	env.buffers.dists.Update(ctx, env.dists)
 	env.prg.Pointer(ctx, env.attribs.dist, 4)
@@ -656,22 +777,101 @@ func (env *Environment) Draw(ctx gl.Context) {
 	env.buffers.indices.Draw(ctx, env.prg, gl.TRIANGLES)
 }
 
+// DrawGridDebug enables the grid overlay, drawn on top of sheets by Draw.
+//
+// Deprecated: Set Debug to include DebugGrid instead.
 func (env *Environment) DrawGridDebug(ctx gl.Context) {
-	env.Grid.draw(ctx, env.View, env.proj)
+	env.Debug |= DebugGrid
+}
+
+// dragger is implemented by sheets following a touch beyond their bounds
+// until released, such as sliders.
+type dragger interface {
+	drag(typ touch.Type, x float32, now time.Time)
+}
+
+// keyer is implemented by sheets handling key events while focused.
+type keyer interface {
+	key(ev key.Event) bool
+}
+
+// Focus gives keyboard focus to sheet, removing it from all others. If sheet
+// is nil, no sheet has focus.
+func (env *Environment) Focus(sheet Sheet) {
+	for _, s := range env.sheets {
+		s.M().SetState(StateFocused, s == sheet)
+	}
+}
+
+// Focused returns sheet with keyboard focus, or nil.
+func (env *Environment) Focused() Sheet {
+	for _, sheet := range env.sheets {
+		if sheet.M().state&StateFocused != 0 && !part(sheet) {
+			return sheet
+		}
+	}
+	return nil
+}
+
+// Key sends ev to the focused sheet, reporting whether it was handled.
+func (env *Environment) Key(ev key.Event) bool {
+	sheet := env.Focused()
+	if sheet == nil || sheet.Hidden() || sheet.M().Disabled() {
+		return false
+	}
+	if k, ok := sheet.(keyer); ok {
+		return k.key(ev)
+	}
+	return false
 }
 
 func (env *Environment) Touch(ev touch.Event) bool {
 	ex, ey := ev.X, float32(windowSize.HeightPx)-ev.Y
 	ev.Y = ey // convert Y coord to bottom = 0, top = max
+
+	if ev.Type == touch.TypeBegin {
+		env.dismissing = env.dismissModal(ex, ey) || env.dismissTemporary(ex, ey)
+	}
+	if env.dismissing {
+		if ev.Type == touch.TypeEnd {
+			env.dismissing = false
+		}
+		return true
+	}
+
+	if d := env.dragging; d != nil && ev.Type != touch.TypeBegin {
+		// sheet follows drags beyond its bounds until released.
+		if ev.Type == touch.TypeEnd {
+			env.dragging = nil
+		}
+		d.drag(ev.Type, ex, env.now())
+		return true
+	}
+
+	if ev.Type == touch.TypeEnd {
+		// release sheets pressed even if touch ends outside their bounds.
+		now := env.now()
+		for _, sheet := range env.sheets {
+			if m := sheet.M(); m.state&StatePressed != 0 && !m.Contains(ex, ey) {
+				m.press(touch.TypeEnd, 0, 0, now)
+			}
+		}
+	}
+
+	modal := env.modal()
 	for i := len(env.sheets) - 1; i >= 0; i-- {
 		sheet := env.sheets[i]
-		if !sheet.Hidden() && sheet.Contains(ex, ey) {
+		if modal != nil && !within(sheet.M(), modal.Material) {
+			continue // blocked by dialog
+		}
+		if !sheet.Hidden() && !part(sheet) && sheet.Contains(ex, ey) {
 			mtrl := sheet.M()
-			mtrl.touch.state = ev.Type
-			mtrl.touch.x, mtrl.touch.y = mtrl.RelativeCoords(ex, ey)
-			if ev.Type == touch.TypeBegin {
-				mtrl.touch.start = time.Now()
+			if mtrl.state&StateDisabled != 0 {
+				return true
 			}
+			pressed := mtrl.state&StatePressed != 0 // before this touch
+			rx, ry := mtrl.RelativeCoords(ex, ey)
+			mtrl.press(ev.Type, rx, ry, env.now())
 
 			switch sheet := sheet.(type) {
 			case *Button:
@@ -688,6 +888,33 @@ func (env *Environment) Touch(ev touch.Event) bool {
 				if sheet.OnTouch != nil {
 					sheet.OnTouch(ev)
 				}
+			// selection controls change when released, if pressed by the same touch.
+			case *Checkbox:
+				if ev.Type == touch.TypeEnd && pressed {
+					sheet.toggle()
+				}
+			case *RadioButton:
+				if ev.Type == touch.TypeEnd && pressed {
+					sheet.check()
+				}
+			case *Switch:
+				sheet.press(ev.Type, env.now())
+				if ev.Type == touch.TypeEnd && pressed {
+					sheet.toggle()
+				}
+			case *Slider:
+				if ev.Type == touch.TypeBegin {
+					env.Focus(sheet)
+					env.dragging = sheet
+					sheet.drag(ev.Type, ex, env.now())
+				}
+			case *Snackbar:
+				if ev.Type == touch.TypeBegin {
+					env.dragging = sheet
+					sheet.drag(ev.Type, ex, env.now())
+				}
+			case *Dialog:
+				// touch is swallowed by dialog.
 			default:
 				log.Printf("Unhandled type %T\n", sheet)
 				continue
@@ -698,27 +925,135 @@ func (env *Environment) Touch(ev touch.Event) bool {
 	return false
 }
 
+// Opacities of shadows cast by key and ambient light.
+const (
+	keyShadowAlpha     = 0.75
+	ambientShadowAlpha = 0.35
+)
+
+// appendShadow appends shadow of material m at elevation z, spread by s and
+// offset below m by s*dy, with opacity alpha.
+func (env *Environment) appendShadow(m *Material, x, y, z, w, h, r, s, dy, alpha float32) {
+	// (r/w) should be a value in range [0.0..0.5] given that a value of 0.5
+	// is an ellipse/circle. mapping this range to [1..3]*z provides a decent
+	// default for resizing ellipses shadows for visibility given current
+	// algorithm in shader.
+	ss := 2 * s
+	// TODO how should roundness scale
+	rr := r * ((w + ss) / w)
+
+	// clamp rr for circular shadows
+	if rr > (w+ss)/2 {
+		rr = (w + ss) / 2
+	}
+
+	x -= s
+	w += ss
+
+	y -= s * dy // offset shadow from material
+	h += ss
+
+	n := uint32(len(env.verts)) / 4
+	env.indices = append(env.indices,
+		n, n+2, n+1,
+		n, n+3, n+2,
+	)
+	env.verts = append(env.verts,
+		x, y, -z, rr, // v0
+		x, y+h, -z, rr, // v1
+		x+w, y+h, -z, rr, // v2
+		x+w, y, -z, rr, // v3
+	)
+	a := alpha * m.ca
+	env.colors = append(env.colors,
+		m.cr, m.cg, m.cb, a,
+		m.cr, m.cg, m.cb, a,
+		m.cr, m.cg, m.cb, a,
+		m.cr, m.cg, m.cb, a,
+	)
+	env.dists = append(env.dists,
+		0.0, 0.0, w, h, // v0 left, bottom
+		0.0, 1.0, w, h, // v1 left, top
+		1.0, 1.0, w, h, // v2 right, top
+		1.0, 0.0, w, h, // v3 right, bottom
+	)
+	env.texcoords = append(env.texcoords,
+		-1, -1, -1, -1,
+		-1, -1, -1, -1,
+		-1, -1, -1, -1,
+		-1, -1, -1, -1,
+	)
+	env.appendInk(4, inkLayer{})
+}
+
+// appendInk appends ripple and state layer attributes of l for n vertices.
+func (env *Environment) appendInk(n int, l inkLayer) {
+	for i := 0; i < n; i++ {
+		env.touches = append(env.touches, l.x, l.y, l.radius, l.alpha)
+		env.inks = append(env.inks, l.r, l.g, l.b, l.state)
+	}
+}
+
+// now returns time of env.Clock, or time.Now if nil.
+func (env *Environment) now() time.Time {
+	if env.Clock != nil {
+		return env.Clock.Now()
+	}
+	return time.Now()
+}
+
+// setSize sets window size and updates grid; sheets are restyled by theme if
+// screen density changed.
+func (env *Environment) setSize(sz size.Event) {
+	restyle := sz.PixelsPerPt != windowSize.PixelsPerPt
+	windowSize = sz
+	env.setGrid()
+	if restyle {
+		env.applyTheme()
+	}
+}
+
+// add appends sheet to environment and styles sheet by theme if Themed.
+func (env *Environment) add(sheet Sheet) {
+	sheet.M().env = env
+	sheet.M().added = env.added
+	env.added++
+	env.sheets = append(env.sheets, sheet)
+	if t, ok := sheet.(Themed); ok {
+		t.ApplyTheme(env.Theme())
+	}
+}
+
+// addedOrder returns sheets of environment in order added, unlike env.sheets
+// which Draw sorts by z.
+func (env *Environment) addedOrder() []Sheet {
+	sheets := append([]Sheet(nil), env.sheets...)
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].M().added < sheets[j].M().added })
+	return sheets
+}
+
+// NewMaterial returns material colored by surface color of theme, with text
+// and icons contrasting its color unless set. Plain materials are not restyled
+// when theme changes.
 func (env *Environment) NewMaterial(ctx gl.Context) *Material {
 	m := New(ctx, Black)
-	m.SetColor(env.plt.Light)
-	env.sheets = append(env.sheets, m)
+	m.SetColor(env.Theme().Surface)
+	env.add(m)
 	return m
 }
 
 func (env *Environment) NewButton(ctx gl.Context) *Button {
 	btn := &Button{Material: New(ctx, Black)} // TODO update constructor to remove color arg
-	btn.SetColor(env.plt.Primary)
-	btn.SetIconColor(White)
-	env.sheets = append(env.sheets, btn)
+	btn.PressedElevation = ElevationRaisedButtonPressed
+	env.add(btn)
 	return btn
 }
 
 func (env *Environment) NewFloatingActionButton(ctx gl.Context) *FloatingActionButton {
 	fab := &FloatingActionButton{Material: New(ctx, Black)} // TODO update constructor to remove color arg
-	fab.SetColor(env.plt.Primary)
-	fab.SetIconColor(White)
 	fab.IsCircle = true
-	env.sheets = append(env.sheets, fab)
+	fab.PressedElevation = ElevationFABPressed
+	env.add(fab)
 	return fab
 }
 
@@ -728,20 +1063,155 @@ func (env *Environment) NewToolbar(ctx gl.Context) *Toolbar {
 		Nav:      env.NewButton(ctx),
 		Title:    env.NewMaterial(ctx),
 	}
-	bar.SetColor(env.plt.Light) // create specific ColorFromPalette on each type to localize selection
 	bar.Nav.BehaviorFlags = DescriptorFlat
+	bar.Nav.Ink.Unbounded = true
 	bar.Nav.SetIcon(icon.NavigationMenu)
-	bar.Nav.SetIconColor(Black)
+	bar.Nav.ApplyTheme(env.Theme())
 	bar.Title.BehaviorFlags = DescriptorFlat
-	env.sheets = append(env.sheets, bar)
+	bar.Title.Role = RoleHeading
+	env.add(bar)
 	return bar
 }
 
 func (env *Environment) NewMenu(ctx gl.Context) *Menu {
 	mu := &Menu{Material: New(ctx, Black)}
-	mu.SetColor(env.plt.Light)
 	mu.BehaviorFlags |= VisibilityTemporary
 	mu.hidden = true
-	env.sheets = append(env.sheets, mu)
+	env.add(mu)
 	return mu
 }
+
+// NewDialog returns hidden dialog of kind; full-screen dialogs have a close
+// button dismissing them.
+func (env *Environment) NewDialog(ctx gl.Context, kind DialogKind) *Dialog {
+	dlg := &Dialog{Material: New(ctx, Black), Kind: kind}
+	dlg.BehaviorFlags = DescriptorRaised | VisibilityTemporary
+	dlg.Ink = Ink{}
+	dlg.hidden = true
+	dlg.Title, dlg.Body = env.newPart(ctx, dlg.Material), env.newPart(ctx, dlg.Material)
+	dlg.Title.Role = RoleHeading
+	env.add(dlg)
+	if kind == DialogFullScreen {
+		// elevated above dialog by its constraints, so touch reaches it first
+		// once Draw sorts sheets by z.
+		dlg.close = env.NewButton(ctx)
+		dlg.close.BehaviorFlags = DescriptorFlat
+		dlg.close.Ink.Unbounded = true
+		dlg.close.Label = "Close"
+		dlg.close.SetIcon(icon.NavigationClose)
+		dlg.close.owner = dlg.Material
+		dlg.close.ApplyTheme(env.Theme())
+		dlg.close.OnPress = func() { dlg.choose(DialogDismissed) }
+	}
+	return dlg
+}
+
+// NewSnackbar returns hidden snackbar shown for SnackbarShort once slid in.
+func (env *Environment) NewSnackbar(ctx gl.Context) *Snackbar {
+	sb := &Snackbar{Material: New(ctx, Black), Timeout: SnackbarShort}
+	sb.BehaviorFlags = DescriptorRaised
+	sb.Ink = Ink{}
+	sb.hidden = true
+	sb.text.middle = true
+	env.add(sb)
+	return sb
+}
+
+// newPart returns flat material added to environment to be drawn as part of
+// owner; parts are hidden with owner and touch events pass through them to the
+// sheets beneath.
+func (env *Environment) newPart(ctx gl.Context, owner *Material) *Material {
+	m := New(ctx, Black)
+	m.BehaviorFlags = DescriptorFlat
+	m.Ink = Ink{}
+	m.owner = owner
+	env.add(m)
+	return m
+}
+
+func (env *Environment) NewCheckbox(ctx gl.Context) *Checkbox {
+	cb := &Checkbox{Material: New(ctx, Black)}
+	cb.box, cb.mark = env.newPart(ctx, cb.Material), env.newPart(ctx, cb.Material)
+	cb.BehaviorFlags = DescriptorFlat
+	cb.Ink.Unbounded, cb.Ink.Radius = true, 20
+	cb.box.SetIcon(icon.ImageCropSquare)
+	cb.mark.BehaviorFlags = DescriptorRaised
+	cb.mark.overlay = true
+	cb.mark.SetIcon(icon.NavigationCheck)
+	env.add(cb)
+	return cb
+}
+
+// NewRadioButton returns radio button of group grp, or of a new group if grp
+// is nil.
+func (env *Environment) NewRadioButton(ctx gl.Context, grp *RadioGroup) *RadioButton {
+	if grp == nil {
+		grp = new(RadioGroup)
+	}
+	rb := &RadioButton{Material: New(ctx, Black), grp: grp}
+	rb.ring, rb.dot = env.newPart(ctx, rb.Material), env.newPart(ctx, rb.Material)
+	rb.BehaviorFlags = DescriptorFlat
+	rb.Ink.Unbounded, rb.Ink.Radius = true, 20
+	rb.ring.SetIcon(icon.ImagePanoramaFishEye)
+	rb.dot.BehaviorFlags = DescriptorRaised
+	rb.dot.overlay = true
+	rb.dot.IsCircle = true
+	grp.buttons = append(grp.buttons, rb)
+	env.add(rb)
+	return rb
+}
+
+// NewSlider returns continuous slider from 0 to 1.
+func (env *Environment) NewSlider(ctx gl.Context) *Slider {
+	return env.newSlider(ctx, false)
+}
+
+// NewRangeSlider returns continuous slider from 0 to 1 selecting a range with
+// two thumbs.
+func (env *Environment) NewRangeSlider(ctx gl.Context) *Slider {
+	return env.newSlider(ctx, true)
+}
+
+func (env *Environment) newSlider(ctx gl.Context, ranged bool) *Slider {
+	sl := &Slider{Material: New(ctx, Black), ValueLabel: true, max: 1, active: 1}
+	sl.BehaviorFlags = DescriptorFlat
+	sl.Ink = Ink{} // ripple and focus are shown by thumbs
+	part := func() *Material {
+		m := env.newPart(ctx, sl.Material)
+		m.BehaviorFlags = DescriptorRaised
+		m.overlay = true
+		return m
+	}
+	sl.track, sl.fill = part(), part()
+	sl.track.Roundness, sl.fill.Roundness = Dp(2).Px(), Dp(2).Px()
+	for i := range sl.thumbs {
+		if i == 0 && !ranged {
+			continue
+		}
+		thumb := part()
+		thumb.IsCircle = true
+		thumb.Ink = DefaultInk
+		thumb.Ink.Unbounded, thumb.Ink.Radius = true, 20
+		sl.thumbs[i] = thumb
+	}
+	sl.label = part()
+	sl.label.IsCircle = true
+	sl.label.hidden = true
+	env.add(sl)
+	return sl
+}
+
+func (env *Environment) NewSwitch(ctx gl.Context) *Switch {
+	sw := &Switch{Material: New(ctx, Black)}
+	sw.track, sw.thumb = env.newPart(ctx, sw.Material), env.newPart(ctx, sw.Material)
+	sw.BehaviorFlags = DescriptorFlat
+	sw.Ink = Ink{} // ripple is shown by thumb
+	sw.track.BehaviorFlags = DescriptorRaised
+	sw.track.overlay = true
+	sw.thumb.BehaviorFlags = DescriptorRaised
+	sw.thumb.IsCircle = true
+	sw.thumb.Ink = DefaultInk
+	sw.thumb.Ink.Unbounded, sw.thumb.Ink.Radius = true, 20
+	env.add(sw)
+	return sw
+}
@@ -0,0 +1,78 @@
+package material
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/mobile/exp/f32"
+)
+
+func TestEnvironmentDo(t *testing.T) {
+	env := new(Environment)
+	var n int
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			env.Do(func() { n++ })
+			env.Animate(Animation{})
+		}()
+	}
+	wg.Wait()
+
+	env.flush()
+	if n != 8 {
+		t.Errorf("have %v calls, want 8", n)
+	}
+	if l := env.Scheduler().Len(); l != 8 {
+		t.Errorf("have %v animations, want 8", l)
+	}
+
+	env.Do(func() { env.Do(func() { n++ }) })
+	env.flush()
+	if n != 8 {
+		t.Errorf("function queued while flushing ran early")
+	}
+	env.flush()
+	if n != 9 {
+		t.Errorf("have %v calls, want 9", n)
+	}
+}
+
+func TestEnvironmentAnimateLayout(t *testing.T) {
+	clock := new(testClock)
+	env := &Environment{Clock: clock, LayoutDur: 100 * time.Millisecond}
+	m := New(nil, Black)
+	env.add(m)
+
+	m.world.Identity()
+	env.prevWorlds = append(env.prevWorlds[:0], m.world)
+	m.world[0][3] = 100
+	want := m.world
+
+	env.animateLayout()
+	if m.world[0][3] != 0 {
+		t.Fatalf("have x %v before first step, want 0", m.world[0][3])
+	}
+	env.Scheduler().Step()
+	clock.Add(50 * time.Millisecond)
+	env.Scheduler().Step()
+	if x := m.world[0][3]; x <= 0 || x >= 100 {
+		t.Errorf("have x %v midway, want between 0 and 100", x)
+	}
+	clock.Add(50 * time.Millisecond)
+	env.Scheduler().Step()
+	if m.world != want {
+		t.Errorf("have %v, want %v", m.world, want)
+	}
+
+	// first layout, without previous world, is not animated.
+	env.prevWorlds[0] = f32.Mat4{}
+	m.world[0][3] = 200
+	env.animateLayout()
+	if m.world[0][3] != 200 {
+		t.Errorf("have x %v, want 200", m.world[0][3])
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/dskinner/material"
-	"github.com/dskinner/snd"
 	"golang.org/x/mobile/app"
 	"golang.org/x/mobile/event/lifecycle"
 	"golang.org/x/mobile/event/paint"
@@ -20,8 +19,8 @@ import (
 var (
 	env    = new(material.Environment)
 	boxes  [9]*material.Material
-	sig    snd.Discrete
-	quits  []chan struct{}
+	sig    material.Curve
+	quits  []*material.Handle
 	colors = []material.Color{material.Red500, material.Pink500, material.Purple500, material.DeepPurple500, material.Indigo500, material.Blue500, material.LightBlue500, material.Cyan500, material.Teal500, material.Green500, material.LightGreen500, material.Lime500, material.Yellow500, material.Amber500, material.Orange500, material.DeepOrange500, material.Brown500, material.Grey500, material.BlueGrey500}
 )
 
@@ -33,15 +32,9 @@ func onStart(ctx gl.Context) {
 		Accent:  material.DeepOrangeA200,
 	})
 
-	quits = []chan struct{}{}
+	quits = []*material.Handle{}
 
-	sig = make(snd.Discrete, len(material.ExpSig))
-	copy(sig, material.ExpSig)
-	rsig := make(snd.Discrete, len(material.ExpSig))
-	copy(rsig, material.ExpSig)
-	rsig.UnitInverse()
-	sig = append(sig, rsig...)
-	sig.NormalizeRange(0, 1)
+	sig = material.Mirror(material.Standard)
 
 	ctx.Enable(gl.BLEND)
 	ctx.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
@@ -65,7 +58,7 @@ func onStop(ctx gl.Context) {
 		boxes[i] = nil
 	}
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 }
 
@@ -95,7 +88,7 @@ func onLayout(sz size.Event) {
 	boxes[4].SetTextHeight(material.Dp(24).Px())
 
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 	quits = quits[:0]
 
@@ -107,7 +100,7 @@ func onLayout(sz size.Event) {
 	func(i int) {
 		m := boxes[i].World()
 		x, z := m[0][3], m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
@@ -115,8 +108,8 @@ func onLayout(sz size.Event) {
 				m[0][3] = x + 200*dt
 				boxes[i].SetColor(colors[i])
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  1000 * time.Millisecond,
 			Loop: true,
@@ -124,27 +117,27 @@ func onLayout(sz size.Event) {
 				m[2][3] = z + 4*dt
 				boxes[i].SetColor(colors[i+1])
 			},
-		}.Do())
+		}))
 	}(1)
 
 	func(i int) {
 		m := boxes[i].World()
 		z := m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				m[2][3] = z + 10*dt
 			},
-		}.Do())
+		}))
 	}(2)
 
 	func(i int) {
 		m := boxes[i].World()
 		x, y := m[0][3], m[1][3]
 		w, h := m[0][0], m[1][1]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  4000 * time.Millisecond,
 			Loop: true,
@@ -154,8 +147,8 @@ func onLayout(sz size.Event) {
 				boxes[4].SetText(fmt.Sprintf("w: %.2f\nh: %.2f", m[0][0], m[1][1]))
 				boxes[i].SetColor(colors[i])
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
@@ -164,14 +157,14 @@ func onLayout(sz size.Event) {
 				m[1][3] = y - 200*dt/2
 				boxes[i].SetColor(colors[i+2])
 			},
-		}.Do())
+		}))
 	}(4)
 
 	func(i int) {
 		m := boxes[i].World()
 		w, h := m[0][0], m[1][1]
 		z := m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  4000 * time.Millisecond,
 			Loop: true,
@@ -181,8 +174,8 @@ func onLayout(sz size.Event) {
 				m[1][1] = h + 200*dt
 				boxes[i].SetColor(colors[i+3])
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  8000 * time.Millisecond,
 			Loop: true,
@@ -190,20 +183,20 @@ func onLayout(sz size.Event) {
 				m[2][3] = z + 7*dt
 				boxes[i].SetColor(colors[i+4])
 			},
-		}.Do())
+		}))
 	}(6)
 
 	func(i int) {
 		m := boxes[i].World()
 		w := m[0][0]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				boxes[i].Roundness = (w / 2) * dt
 			},
-		}.Do())
+		}))
 	}(8)
 
 	_ = f32.Vec3{}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/dskinner/material"
-	"github.com/dskinner/snd"
 	"golang.org/x/mobile/app"
 	"golang.org/x/mobile/event/lifecycle"
 	"golang.org/x/mobile/event/paint"
@@ -19,8 +18,8 @@ import (
 var (
 	env   = new(material.Environment)
 	boxes [9]*material.Material
-	sig   snd.Discrete
-	quits []chan struct{}
+	sig   material.Curve
+	quits []*material.Handle
 )
 
 func onStart(ctx gl.Context) {
@@ -31,15 +30,9 @@ func onStart(ctx gl.Context) {
 		Accent:  material.DeepOrangeA200,
 	})
 
-	quits = []chan struct{}{}
+	quits = []*material.Handle{}
 
-	sig = make(snd.Discrete, len(material.ExpSig))
-	copy(sig, material.ExpSig)
-	rsig := make(snd.Discrete, len(material.ExpSig))
-	copy(rsig, material.ExpSig)
-	rsig.UnitInverse()
-	sig = append(sig, rsig...)
-	sig.NormalizeRange(0, 1)
+	sig = material.Mirror(material.Standard)
 
 	ctx.Enable(gl.BLEND)
 	ctx.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
@@ -62,7 +55,7 @@ func onStop(ctx gl.Context) {
 		boxes[i] = nil
 	}
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 }
 
@@ -92,7 +85,7 @@ func onLayout(sz size.Event) {
 	boxes[4].SetTextHeight(material.Dp(24).Px())
 
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 	quits = quits[:0]
 
@@ -104,42 +97,42 @@ func onLayout(sz size.Event) {
 	func() {
 		m := boxes[1].World()
 		x, z := m[0][3], m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				m[0][3] = x + 200*dt
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  1000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				m[2][3] = z + 4*dt
 			},
-		}.Do())
+		}))
 	}()
 
 	func() {
 		m := boxes[2].World()
 		z := m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				m[2][3] = z + 10*dt
 			},
-		}.Do())
+		}))
 	}()
 
 	func() {
 		m := boxes[4].World()
 		x, y := m[0][3], m[1][3]
 		w, h := m[0][0], m[1][1]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  4000 * time.Millisecond,
 			Loop: true,
@@ -148,8 +141,8 @@ func onLayout(sz size.Event) {
 				m[0][3] = x - 200*dt/2
 				boxes[4].SetText(fmt.Sprintf("w: %.2f\nh: %.2f", m[0][0], m[1][1]))
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
@@ -157,14 +150,14 @@ func onLayout(sz size.Event) {
 				m[1][1] = h + 200*dt
 				m[1][3] = y - 200*dt/2
 			},
-		}.Do())
+		}))
 	}()
 
 	func() {
 		m := boxes[6].World()
 		w, h := m[0][0], m[1][1]
 		z := m[2][3]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  4000 * time.Millisecond,
 			Loop: true,
@@ -173,28 +166,28 @@ func onLayout(sz size.Event) {
 				m[0][0] = w + 200*dt
 				m[1][1] = h + 200*dt
 			},
-		}.Do())
-		quits = append(quits, material.Animation{
+		}))
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  8000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				m[2][3] = z + 7*dt
 			},
-		}.Do())
+		}))
 	}()
 
 	func() {
 		m := boxes[8].World()
 		w := m[0][0]
-		quits = append(quits, material.Animation{
+		quits = append(quits, env.Animate(material.Animation{
 			Sig:  sig,
 			Dur:  2000 * time.Millisecond,
 			Loop: true,
 			Interp: func(dt float32) {
 				boxes[8].Roundness = (w / 2) * dt
 			},
-		}.Do())
+		}))
 	}()
 
 	_ = f32.Vec3{}
@@ -36,6 +36,7 @@ func onStart(ctx gl.Context) {
 
 	env.Load(ctx)
 	env.LoadGlyphs(ctx)
+	env.Scheduler().Resume()
 
 	showBtn = env.NewButton(ctx)
 	showBtn.SetTextColor(material.White)
@@ -90,6 +91,7 @@ func b2Action(ev touch.Event) {
 }
 
 func onStop(ctx gl.Context) {
+	env.Scheduler().Pause()
 	env.Unload(ctx)
 }
 
@@ -8,8 +8,8 @@ import (
 	"time"
 
 	"github.com/dskinner/material"
-	"github.com/dskinner/snd"
 	"golang.org/x/mobile/app"
+	"golang.org/x/mobile/event/key"
 	"golang.org/x/mobile/event/lifecycle"
 	"golang.org/x/mobile/event/paint"
 	"golang.org/x/mobile/event/size"
@@ -18,11 +18,11 @@ import (
 )
 
 var (
-	env                               = new(material.Environment)
-	slider, indicator, btnMin, btnMax *material.Button
-	readout                           *material.Material
-	sig                               snd.Discrete
-	quits                             []chan struct{}
+	env            = new(material.Environment)
+	volume, octave *material.Slider
+	btnMin, btnMax *material.Button
+	readout        *material.Material
+	quits          []*material.Handle
 )
 
 func onStart(ctx gl.Context) {
@@ -33,15 +33,7 @@ func onStart(ctx gl.Context) {
 		Accent:  material.DeepOrangeA200,
 	})
 
-	quits = []chan struct{}{}
-
-	sig = make(snd.Discrete, len(material.ExpSig))
-	copy(sig, material.ExpSig)
-	rsig := make(snd.Discrete, len(material.ExpSig))
-	copy(rsig, material.ExpSig)
-	rsig.UnitInverse()
-	sig = append(sig, rsig...)
-	sig.NormalizeRange(0, 1)
+	quits = []*material.Handle{}
 
 	ctx.Enable(gl.BLEND)
 	ctx.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
@@ -52,136 +44,51 @@ func onStart(ctx gl.Context) {
 	env.Load(ctx)
 	env.LoadGlyphs(ctx)
 
-	slider = env.NewButton(ctx)
-	slider.SetColor(env.Palette().Dark)
-	slider.OnTouch = setSlider
+	// continuous slider
+	volume = env.NewSlider(ctx)
+	volume.SetBounds(0, 100, 0)
+	volume.Format = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	volume.OnChange = func(lo, hi float64) { updateReadout() }
+
+	// discrete range slider with tick marks
+	octave = env.NewRangeSlider(ctx)
+	octave.SetBounds(1, 8, 1)
+	octave.SetRange(3, 5)
+	octave.OnChange = func(lo, hi float64) { updateReadout() }
 
 	btnMin = env.NewButton(ctx)
-	btnMin.SetColor(env.Palette().Primary)
-	btnMin.OnTouch = sliderMin
+	btnMin.OnPress = func() { slideTo(volume, 0) }
 	btnMin.SetText("Min")
 
 	btnMax = env.NewButton(ctx)
-	btnMax.SetColor(env.Palette().Primary)
-	btnMax.OnTouch = sliderMax
+	btnMax.OnPress = func() { slideTo(volume, 100) }
 	btnMax.SetText("Max")
 
-	indicator = env.NewButton(ctx)
-	indicator.SetColor(env.Palette().Accent)
-	indicator.OnTouch = setSlider
-	indicator.Roundness = 5
-
 	readout = env.NewMaterial(ctx)
 	readout.SetColor(env.Palette().Light)
 	readout.Roundness = 5
-
 }
 
-func setSlider(ev touch.Event) {
-	mi := indicator.World()
-	ms := slider.World()
-	max := ms[1][1] - mi[1][1] + ms[1][3]
-	if ev.Y > max {
-		ev.Y = max
-	}
-	log.Printf("ev.Y: %v, max: %v\n", ev.Y, max)
-	quits = append(quits, material.Animation{
-		Sig:  sig,
-		Dur:  200 * time.Millisecond,
-		Loop: false,
-		Start: func() {
-			readout.SetText(heightNormalized(ev.Y))
-		},
+// slideTo springs value of sl to v.
+func slideTo(sl *material.Slider, v float64) {
+	from := sl.Value()
+	quits = append(quits, env.Animate(material.DefaultSpring.Apply(material.Animation{
 		Interp: func(dt float32) {
-			if ev.Y >= (ms[1][3] + 0.5*ms[1][1]) {
-				mi[1][3] += (ev.Y - mi[1][3]) * dt
-			} else {
-				mi[1][3] -= (mi[1][3] - ev.Y) * dt
-			}
-		},
-		End: func() {
-			mi[1][3] = ev.Y
+			sl.SetValue(from + (v-from)*float64(dt))
+			updateReadout()
 		},
-	}.Do())
+	})))
 }
 
-func sliderMin(ev touch.Event) {
-	if ev.Type == touch.TypeBegin {
-		m := indicator.World()
-		m2 := slider.World()
-		y2 := m2[1][3]
-		quits = append(quits, material.Animation{
-			Sig:  sig,
-			Dur:  500 * time.Millisecond,
-			Loop: false,
-			Start: func() {
-				readout.SetText(heightNormalized(y2))
-			},
-			Interp: func(dt float32) {
-				m[1][3] -= (m[1][3] - y2) * dt
-				// don't use 'y' in place of m[1][3] here so the value of m[1][3] is
-				// evaluated each step in the loop
-			},
-			End: func() {
-				m[1][3] = y2
-			},
-		}.Do())
-	}
+func updateReadout() {
+	lo, hi := octave.Range()
+	readout.SetText(fmt.Sprintf("%.0f%% %v-%v", volume.Value(), lo, hi))
 }
 
-func sliderMax(ev touch.Event) {
-	// this only goes to 99% in landscape mode on my phone...
-	if ev.Type == touch.TypeBegin {
-		mi := indicator.World()
-		hi := mi[1][1]
-		ms := slider.World()
-		ys := ms[1][3]
-		hs := ms[1][1]
-		max := hs - hi + ys
-		log.Printf("ev.Y: %v, max: %v\n", ev.Y, max)
-		quits = append(quits, material.Animation{
-			Sig:  sig,
-			Dur:  500 * time.Millisecond,
-			Loop: false,
-			Start: func() {
-				readout.SetText(heightNormalized(max))
-			},
-			Interp: func(dt float32) {
-				mi[1][3] -= (mi[1][3] - (max)) * dt
-			},
-			End: func() {
-				mi[1][3] = max
-			},
-		}.Do())
-	}
-}
-
-func heightNormalized(f float32) string {
-	m := slider.World()
-	y, h, hi := m[1][3], m[1][1], indicator.World()[1][1]
-	return fmt.Sprintf("%v", int((f-y)/(h-hi)*100))
-}
-
-func dumpWorld(ob *material.Material) {
-	for i, sl := range ob.World() {
-		log.Printf("i: %v, sl: %v\n", i, sl)
-	}
-}
-
-//		m := slider.World()
-//		w, x := m[0][0], m[0][3]
-//    h, y := m[1][1], m[1][3]
-//		z	   := m[2][3]
-//		example matrix
-//		[60.00,		0.0,		0.0,	1300.0]
-//		[	0.00,	100.0,		0.0,	2000.0]
-//		[	0.00,		0.0,		1.0,		2.00]
-//		[	0.00,		0.0,		0.0,		1.00]
-
 func onStop(ctx gl.Context) {
 	env.Unload(ctx)
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 }
 
@@ -190,21 +97,17 @@ func onLayout(sz size.Event) {
 	env.StartLayout()
 
 	for _, q := range quits {
-		q <- struct{}{}
+		q.Cancel()
 	}
 	quits = quits[:0]
 
 	b, g, s := env.Box, env.Grid.Gutter, env.Grid.StepSize()
-	// env.Grid.StepSize is based on # columns, there must be a better way to set
-	// heights adaptively. Setting the slider.Height to anything more than 0.6 *
-	// screen ht makes the "max" button get lost in landscape mode
 	env.AddConstraints(
-		slider.Width(s/4), slider.Height(float32(sz.HeightPx)*0.6),
-		slider.Z(1), slider.CenterHorizontalIn(b), slider.StartIn(b, g),
-		indicator.Width(s/4*0.9), indicator.Height(s/4*1.5), indicator.Z(2), indicator.CenterHorizontalIn(slider.Box), indicator.CenterVerticalIn(slider.Box),
-		readout.Width(1*s), readout.Height(s/2), readout.Z(4), readout.CenterHorizontalIn(b), readout.Above(btnMin.Box, g), readout.Below(slider.Box, g),
-		btnMax.Width(1*s), btnMax.Height(s/2), btnMax.Z(4), btnMax.CenterHorizontalIn(b), btnMax.Above(slider.Box, 4*g),
-		btnMin.Width(1*s), btnMin.Height(s/2), btnMin.Z(4), btnMin.CenterHorizontalIn(b),
+		readout.Width(2*s), readout.Height(s/2), readout.Z(1), readout.CenterHorizontalIn(b), readout.TopIn(b, 4*g),
+		volume.Width(4*s), volume.Z(1), volume.CenterHorizontalIn(b), volume.Below(readout.Box, 2*g),
+		octave.Width(4*s), octave.Z(1), octave.CenterHorizontalIn(b), octave.Below(volume.Box, 2*g),
+		btnMin.Width(s), btnMin.Height(s/2), btnMin.Z(2), btnMin.StartIn(volume.Box, 0), btnMin.Below(octave.Box, 2*g),
+		btnMax.Width(s), btnMax.Height(s/2), btnMax.Z(2), btnMax.EndIn(volume.Box, 0), btnMax.Below(octave.Box, 2*g),
 	)
 
 	log.Println("starting layout")
@@ -213,19 +116,13 @@ func onLayout(sz size.Event) {
 	log.Printf("finished layout in %s\n", time.Now().Sub(t))
 
 	readout.SetTextHeight(s / 2 * 0.85)
-	readout.SetText(heightNormalized(indicator.World()[1][3]))
+	updateReadout()
 }
 
-var lastpaint time.Time
-var fps int
-
 func onPaint(ctx gl.Context) {
 	ctx.ClearColor(material.BlueGrey100.RGBA())
 	ctx.Clear(gl.COLOR_BUFFER_BIT)
 	env.Draw(ctx)
-	now := time.Now()
-	fps = int(time.Second / now.Sub(lastpaint))
-	lastpaint = now
 }
 
 func main() {
@@ -257,8 +154,9 @@ func main() {
 				a.Publish()
 				a.Send(paint.Event{})
 			case touch.Event:
-				//log.Printf("Touch event: %v\n", ev)
 				env.Touch(ev)
+			case key.Event:
+				env.Key(ev)
 			}
 		}
 	})
@@ -2,7 +2,6 @@ module dasa.cc/material
 
 require (
 	dasa.cc/simplex v0.0.0-20180617055632-ae0aeef7c530
-	dasa.cc/snd v0.0.0-20180617055848-131b2504d0d2
 	golang.org/x/image v0.0.0-20180926015637-991ec62608f3
 	golang.org/x/mobile v0.0.0-20180922163855-920b52be609a
 	golang.org/x/sys v0.0.0-20180928133829-e4b3c5e90611 // indirect
@@ -0,0 +1,174 @@
+package material
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/mobile/event/touch"
+)
+
+// State is a set of interaction states of a material, shown by its state layer,
+// and states reported to assistive technologies.
+type State int
+
+const (
+	StateHovered State = 1 << iota
+	StateFocused
+	StatePressed
+	StateDragged
+
+	// StateDisabled materials ignore touch events.
+	StateDisabled
+	StateSelected
+	StateExpanded
+)
+
+// Ink configures touch feedback of a material; a ripple expanding from the
+// point of touch while pressed, and a state layer overlaying the material
+// while hovered, focused or dragged.
+// https://material.io/guidelines/motion/choreography.html#choreography-radial-reaction
+type Ink struct {
+	// Color of ripple and state layer. If zero, content color of material is used.
+	Color Color
+
+	// Unbounded ripples expand from the center of material to Radius beyond
+	// its bounds, such as for icon buttons. If Radius is zero, ripple covers
+	// the material. Bounded ripples expand from the point of touch until the
+	// material is covered.
+	Unbounded bool
+	Radius    Dp
+
+	// Opacity of state layer for each state; Pressed is opacity of ripple.
+	Hovered, Focused, Pressed, Dragged float32
+
+	// Expand is duration of ripple expanding, and Fade of ripple fading once
+	// released and expanded.
+	Expand, Fade time.Duration
+}
+
+// DefaultInk is ink of materials with dark content on light surfaces.
+// Materials with light content typically double opacities.
+var DefaultInk = Ink{
+	Hovered: 0.04,
+	Focused: 0.12,
+	Pressed: 0.12,
+	Dragged: 0.08,
+	Expand:  225 * time.Millisecond,
+	Fade:    150 * time.Millisecond,
+}
+
+// SetState adds or, if on is false, removes states s from material.
+func (mtrl *Material) SetState(s State, on bool) {
+	if on {
+		mtrl.state |= s
+	} else {
+		mtrl.state &^= s
+	}
+}
+
+// State returns current interaction states of material.
+func (mtrl *Material) State() State { return mtrl.state }
+
+// press updates state and ripple of material for touch event of type typ at
+// x, y relative to material.
+func (mtrl *Material) press(typ touch.Type, x, y float32, now time.Time) {
+	mtrl.touch.state = typ
+	switch typ {
+	case touch.TypeBegin:
+		mtrl.touch.x, mtrl.touch.y = x, y
+		mtrl.touch.start, mtrl.touch.end = now, time.Time{}
+		mtrl.SetState(StatePressed, true)
+		mtrl.pressLift(true)
+	case touch.TypeEnd:
+		if mtrl.state&StatePressed != 0 {
+			mtrl.touch.end = now
+			mtrl.pressLift(false)
+		}
+		mtrl.SetState(StatePressed, false)
+	}
+}
+
+// inkLayer is touch feedback of a material at an instant, as drawn.
+type inkLayer struct {
+	x, y, radius, alpha float32 // ripple; relative to material in pixels
+	r, g, b, state      float32 // color and opacity of state layer
+}
+
+// contentColor returns color of content drawn on material; icon color if
+// material has an icon, else text color.
+func (mtrl *Material) contentColor(th Theme) (r, g, b float32) {
+	if mtrl.icon.x != -1 {
+		r, g, b, _ = mtrl.iconColor(th)
+	} else {
+		r, g, b, _ = mtrl.textColor(th)
+	}
+	return r, g, b
+}
+
+// inkAt returns touch feedback of material at now styled by th.
+func (mtrl *Material) inkAt(now time.Time, th Theme) (l inkLayer) {
+	ink := mtrl.Ink
+	if ink.Color != 0 {
+		l.r, l.g, l.b, _ = ink.Color.RGBA()
+	} else {
+		l.r, l.g, l.b = mtrl.contentColor(th)
+	}
+
+	for _, x := range []struct {
+		s State
+		a float32
+	}{{StateHovered, ink.Hovered}, {StateFocused, ink.Focused}, {StateDragged, ink.Dragged}} {
+		if mtrl.state&x.s != 0 && x.a > l.state {
+			l.state = x.a
+		}
+	}
+
+	start, end := mtrl.touch.start, mtrl.touch.end
+	if start.IsZero() {
+		return l
+	}
+
+	w, h := mtrl.world[0][0], mtrl.world[1][1]
+	var max float32
+	if ink.Unbounded {
+		l.x, l.y = w/2, h/2
+		if max = ink.Radius.Px(); max == 0 {
+			max = float32(math.Hypot(float64(w), float64(h))) / 2
+		}
+	} else {
+		// distance to farthest corner covers material.
+		l.x, l.y = mtrl.touch.x*w, mtrl.touch.y*h
+		dx, dy := float64(l.x), float64(l.y)
+		if l.x < w/2 {
+			dx = float64(w - l.x)
+		}
+		if l.y < h/2 {
+			dy = float64(h - l.y)
+		}
+		max = float32(math.Hypot(dx, dy))
+	}
+
+	t := 1.0
+	if ink.Expand > 0 {
+		t = math.Min(1, float64(now.Sub(start))/float64(ink.Expand))
+	}
+	l.radius = max * float32(0.2+0.8*Decelerate.At(t))
+	l.alpha = ink.Pressed
+
+	if !end.IsZero() {
+		if fade := start.Add(ink.Expand); fade.After(end) {
+			end = fade
+		}
+		f := float64(1)
+		if ink.Fade > 0 {
+			f = float64(now.Sub(end)) / float64(ink.Fade)
+		}
+		switch {
+		case f >= 1:
+			l.radius, l.alpha = 0, 0
+		case f > 0:
+			l.alpha *= float32(1 - f)
+		}
+	}
+	return l
+}
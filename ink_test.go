@@ -0,0 +1,50 @@
+package material
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/mobile/event/touch"
+)
+
+func TestMaterialInk(t *testing.T) {
+	m := New(nil, White)
+	m.world.Identity()
+	m.world[0][0], m.world[1][1] = 40, 30
+
+	t0 := time.Now()
+	if l := m.inkAt(t0, DefaultTheme); l.radius != 0 || l.alpha != 0 || l.state != 0 {
+		t.Fatalf("have ink %+v before touch", l)
+	}
+	if l := m.inkAt(t0, DefaultTheme); l.r != 0 || l.g != 0 || l.b != 0 {
+		t.Errorf("have ink color %v %v %v on white, want black", l.r, l.g, l.b)
+	}
+
+	m.press(touch.TypeBegin, 0, 0, t0)
+	l := m.inkAt(t0.Add(m.Ink.Expand), DefaultTheme)
+	if l.radius != 50 || l.alpha != m.Ink.Pressed {
+		t.Errorf("have radius %v alpha %v when expanded, want 50 %v", l.radius, l.alpha, m.Ink.Pressed)
+	}
+
+	// released before expanded; fade starts once expanded.
+	m.press(touch.TypeEnd, 0, 0, t0.Add(10*time.Millisecond))
+	if l := m.inkAt(t0.Add(m.Ink.Expand), DefaultTheme); l.alpha != m.Ink.Pressed {
+		t.Errorf("have alpha %v, want %v", l.alpha, m.Ink.Pressed)
+	}
+	if l := m.inkAt(t0.Add(m.Ink.Expand+m.Ink.Fade/2), DefaultTheme); math.Abs(float64(l.alpha-m.Ink.Pressed/2)) > 1e-6 {
+		t.Errorf("have alpha %v halfway faded, want %v", l.alpha, m.Ink.Pressed/2)
+	}
+	if l := m.inkAt(t0.Add(m.Ink.Expand+m.Ink.Fade), DefaultTheme); l.alpha != 0 {
+		t.Errorf("have alpha %v when faded, want 0", l.alpha)
+	}
+
+	m.SetState(StateHovered|StateFocused, true)
+	if l := m.inkAt(t0, DefaultTheme); l.state != m.Ink.Focused {
+		t.Errorf("have state opacity %v, want %v", l.state, m.Ink.Focused)
+	}
+	m.SetState(StateFocused, false)
+	if m.State() != StateHovered {
+		t.Errorf("have state %v, want %v", m.State(), StateHovered)
+	}
+}
@@ -94,6 +94,28 @@ func (a Box) AlignTops(b Box, by float32) simplex.Constraint {
 	return simplex.Constrain(simplex.Coef{1, b.t}, simplex.Coef{-1, a.t}).GreaterEq(float64(by))
 }
 
+// ColumnSpan returns constraint sizing a to span n columns of grid gd.
+func (a Box) ColumnSpan(gd *Grid, n int) simplex.Constraint {
+	return a.Width(gd.SpanWidth(n))
+}
+
+// StartColumn returns constraint placing start of a at column i, zero-based,
+// of grid gd where b spans the width of the window.
+func (a Box) StartColumn(b Box, gd *Grid, i int) simplex.Constraint {
+	return simplex.Constrain(simplex.Coef{1, a.l}, simplex.Coef{-1, b.l}).Equal(float64(gd.ColumnStart(i)))
+}
+
+// Columns returns constraints placing a at column i, zero-based, of grid gd
+// spanning n columns, where b spans the width of the window. Value of i is
+// clamped as by Grid.ColumnStart and n to the columns from i to the last.
+func (a Box) Columns(b Box, gd *Grid, i, n int) []simplex.Constraint {
+	i = gd.clamp(i+1, 1) - 1
+	if max := gd.Columns - i; n > max {
+		n = max
+	}
+	return []simplex.Constraint{a.StartColumn(b, gd, i), a.ColumnSpan(gd, n)}
+}
+
 func (a Box) Bounds(l, r, b, t float32) []simplex.Constraint {
 	return []simplex.Constraint{
 		simplex.Constrain(simplex.Coef{1, a.l}).GreaterEq(float64(l)),
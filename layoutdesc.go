@@ -0,0 +1,256 @@
+package material
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dskinner/simplex"
+)
+
+// Layout is a declarative description of constraints between named sheets.
+//
+// Layout is decoded from a JSON object mapping sheet names to a list of
+// relations. Each relation is a Box or Material method name followed by
+// space separated arguments, for example:
+//
+//	{
+//		"title": ["Height 56dp", "Z 4", "TopIn env margin", "Columns env 0 4"],
+//		"fab":   ["Z 6", "Below title gutter", "EndIn env margin"]
+//	}
+//
+// Arguments naming a box refer to a sheet bound with Environment.BindName, or
+// env for the environment's box. Lengths are pixels, dp when suffixed with dp
+// such as 16dp, or one of margin, gutter and step of the environment's grid.
+// Elevations along the z-axis, such as of Z and Elevate, are plain numbers in dp.
+//
+// Span sets the spans of a sheet used by its later SpanColumns relations, so
+// it must come before them.
+type Layout map[string][]string
+
+// relations maps supported relations to argument kinds; b is a box, l is a
+// length, z is an elevation and i is an integer.
+var relations = map[string]string{
+	"Width": "l", "Height": "l", "Z": "z", "Elevate": "z",
+	"Start": "l", "End": "l", "Bottom": "l", "Top": "l",
+	"StartIn": "bl", "EndIn": "bl", "BottomIn": "bl", "TopIn": "bl",
+	"CenterVerticalIn": "b", "CenterHorizontalIn": "b",
+	"Before": "bl", "After": "bl", "Below": "bl", "Above": "bl",
+	"AlignBottoms": "bl", "AlignTops": "bl", "ElevateAbove": "bz",
+	"ColumnSpan": "i", "StartColumn": "bi", "Columns": "bii",
+	"Span": "iii", "SpanColumns": "i",
+}
+
+// ParseLayout decodes a layout from r and validates the syntax of each relation.
+// Names of sheets are resolved when the layout is added to an environment.
+func ParseLayout(r io.Reader) (Layout, error) {
+	var lay Layout
+	if err := json.NewDecoder(r).Decode(&lay); err != nil {
+		return nil, fmt.Errorf("material: decode layout: %v", err)
+	}
+	for name, rels := range lay {
+		for _, rel := range rels {
+			fields := strings.Fields(rel)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("material: %s: empty relation", name)
+			}
+			kinds, ok := relations[fields[0]]
+			if !ok {
+				return nil, fmt.Errorf("material: %s: unknown relation %q", name, fields[0])
+			}
+			if len(fields)-1 != len(kinds) {
+				return nil, fmt.Errorf("material: %s: %s takes %v arguments, have %v", name, fields[0], len(kinds), len(fields)-1)
+			}
+		}
+	}
+	return lay, nil
+}
+
+// BindName binds sheet to name for use in layouts added with AddLayout.
+func (env *Environment) BindName(name string, sheet Sheet) {
+	if env.names == nil {
+		env.names = make(map[string]Sheet)
+	}
+	env.names[name] = sheet
+}
+
+// AddLayout adds constraints described by lay. It must be called between
+// StartLayout and FinishLayout. If any relation is invalid, an error is
+// returned and neither constraints nor spans of lay are applied.
+func (env *Environment) AddLayout(lay Layout) (err error) {
+	names := make([]string, 0, len(lay))
+	for name := range lay {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		cns   []simplex.Constraint
+		spans = make(map[*Material][3]int) // before lay, restored on error
+	)
+	defer func() {
+		if err != nil {
+			for m, s := range spans {
+				m.col4, m.col8, m.col12 = s[0], s[1], s[2]
+			}
+		}
+	}()
+	for _, name := range names {
+		sheet, ok := env.names[name]
+		if !ok {
+			return fmt.Errorf("material: no sheet bound to name %q", name)
+		}
+		m := sheet.M()
+		spans[m] = [3]int{m.col4, m.col8, m.col12}
+		spanned := false // by SpanColumns
+		for _, rel := range lay[name] {
+			fields := strings.Fields(rel)
+			if len(fields) != 0 && fields[0] == "Span" && spanned {
+				return fmt.Errorf("material: %s: %q: Span after SpanColumns", name, rel)
+			}
+			c, err := env.relate(sheet, fields)
+			if err != nil {
+				return fmt.Errorf("material: %s: %q: %v", name, rel, err)
+			}
+			spanned = spanned || fields[0] == "SpanColumns"
+			cns = append(cns, c...)
+		}
+	}
+	env.AddConstraints(cns...)
+	return nil
+}
+
+// relate returns constraints on sheet for relation fields; fields[0] is the
+// relation name followed by arguments.
+func (env *Environment) relate(sheet Sheet, fields []string) ([]simplex.Constraint, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty relation")
+	}
+	kinds, ok := relations[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown relation")
+	}
+	if len(fields)-1 != len(kinds) {
+		return nil, fmt.Errorf("takes %v arguments", len(kinds))
+	}
+
+	var (
+		b    Box
+		ls   []float32
+		is   []int
+		err  error
+		args = fields[1:]
+	)
+	for i, kind := range kinds {
+		switch kind {
+		case 'b':
+			b, err = env.layoutBox(args[i])
+		case 'l':
+			var l float32
+			l, err = env.layoutLength(args[i])
+			ls = append(ls, l)
+		case 'z':
+			var z float64
+			z, err = strconv.ParseFloat(args[i], 32)
+			ls = append(ls, float32(z))
+		case 'i':
+			var n int
+			n, err = strconv.Atoi(args[i])
+			is = append(is, n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m := sheet.M()
+	a := m.Box
+	one := func(c simplex.Constraint) []simplex.Constraint { return []simplex.Constraint{c} }
+	switch fields[0] {
+	case "Width":
+		return one(a.Width(ls[0])), nil
+	case "Height":
+		return one(a.Height(ls[0])), nil
+	case "Z":
+		return one(a.Z(ls[0])), nil
+	case "Elevate":
+		return one(a.Elevate(Elevation(ls[0]))), nil
+	case "Start":
+		return one(a.Start(ls[0])), nil
+	case "End":
+		return one(a.End(ls[0])), nil
+	case "Bottom":
+		return one(a.Bottom(ls[0])), nil
+	case "Top":
+		return one(a.Top(ls[0])), nil
+	case "StartIn":
+		return one(a.StartIn(b, ls[0])), nil
+	case "EndIn":
+		return one(a.EndIn(b, ls[0])), nil
+	case "BottomIn":
+		return one(a.BottomIn(b, ls[0])), nil
+	case "TopIn":
+		return one(a.TopIn(b, ls[0])), nil
+	case "CenterVerticalIn":
+		return one(a.CenterVerticalIn(b)), nil
+	case "CenterHorizontalIn":
+		return one(a.CenterHorizontalIn(b)), nil
+	case "Before":
+		return one(a.Before(b, ls[0])), nil
+	case "After":
+		return one(a.After(b, ls[0])), nil
+	case "Below":
+		return one(a.Below(b, ls[0])), nil
+	case "Above":
+		return one(a.Above(b, ls[0])), nil
+	case "AlignBottoms":
+		return one(a.AlignBottoms(b, ls[0])), nil
+	case "AlignTops":
+		return one(a.AlignTops(b, ls[0])), nil
+	case "ElevateAbove":
+		return one(a.ElevateAbove(b, ls[0])), nil
+	case "ColumnSpan":
+		return one(a.ColumnSpan(env.Grid, is[0])), nil
+	case "StartColumn":
+		return one(a.StartColumn(b, env.Grid, is[0])), nil
+	case "Columns":
+		return a.Columns(b, env.Grid, is[0], is[1]), nil
+	case "Span":
+		m.Span(is[0], is[1], is[2])
+		return nil, nil
+	case "SpanColumns":
+		return m.SpanColumns(env, is[0]), nil
+	}
+	return nil, fmt.Errorf("unhandled relation")
+}
+
+func (env *Environment) layoutBox(name string) (Box, error) {
+	if name == "env" {
+		return env.Box, nil
+	}
+	sheet, ok := env.names[name]
+	if !ok {
+		return Box{}, fmt.Errorf("no sheet bound to name %q", name)
+	}
+	return sheet.M().Box, nil
+}
+
+func (env *Environment) layoutLength(s string) (float32, error) {
+	switch s {
+	case "margin":
+		return env.Grid.Margin, nil
+	case "gutter":
+		return env.Grid.Gutter, nil
+	case "step":
+		return env.Grid.StepSize(), nil
+	}
+	if strings.HasSuffix(s, "dp") {
+		x, err := strconv.ParseFloat(strings.TrimSuffix(s, "dp"), 32)
+		return Dp(x).Px(), err
+	}
+	x, err := strconv.ParseFloat(s, 32)
+	return float32(x), err
+}
@@ -0,0 +1,85 @@
+package material
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLayout(t *testing.T) {
+	lay, err := ParseLayout(strings.NewReader(`{
+		"title": ["Height 56dp", "TopIn env margin", "Columns env 0 4"],
+		"fab": ["Below title gutter", "Span 4 8 12", "SpanColumns 0"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lay["title"]) != 3 || len(lay["fab"]) != 3 {
+		t.Errorf("unexpected layout %v", lay)
+	}
+
+	for _, src := range []string{
+		`{"a": ["Nope 1"]}`,
+		`{"a": ["Width"]}`,
+		`{"a": ["Below env"]}`,
+		`{"a": [""]}`,
+		`["Width 1"]`,
+	} {
+		if _, err := ParseLayout(strings.NewReader(src)); err == nil {
+			t.Errorf("expected error parsing %s", src)
+		}
+	}
+}
+
+func TestEnvironmentLayoutLength(t *testing.T) {
+	env := &Environment{Grid: &Grid{Margin: 16, Gutter: 24, Columns: 4}}
+	for _, tt := range []struct {
+		s string
+		x float32
+	}{{"margin", 16}, {"gutter", 24}, {"12", 12}, {"-2.5", -2.5}} {
+		if x, err := env.layoutLength(tt.s); err != nil || x != tt.x {
+			t.Errorf("%q: have %v, %v; want %v", tt.s, x, err, tt.x)
+		}
+	}
+	if _, err := env.layoutLength("wide"); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := env.layoutBox("missing"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestRelateElevation(t *testing.T) {
+	env := &Environment{Grid: &Grid{Margin: 16, Gutter: 24, Columns: 4}}
+	m := New(nil, White)
+	if _, err := env.relate(m, []string{"Elevate", "8"}); err != nil {
+		t.Errorf("Elevate 8: %v", err)
+	}
+	for _, z := range []string{"8dp", "margin"} {
+		if _, err := env.relate(m, []string{"Elevate", z}); err == nil {
+			t.Errorf("Elevate %s: expected error", z)
+		}
+	}
+}
+
+func TestAddLayoutInvalid(t *testing.T) {
+	// without a layout program, adding any constraint panics.
+	env := &Environment{Grid: &Grid{Margin: 16, Gutter: 24, Columns: 4}}
+	a, b := New(nil, White), New(nil, White)
+	a.Span(2, 4, 6)
+	env.BindName("a", a)
+	env.BindName("b", b)
+
+	for _, lay := range []Layout{
+		{"a": {""}},
+		{"a": {"   "}},
+		{"a": {"Span 4 8 12", "Width 10"}, "b": {"Width wide"}},
+		{"a": {"SpanColumns 0", "Span 4 8 12"}},
+	} {
+		if err := env.AddLayout(lay); err == nil {
+			t.Errorf("%v: expected error", lay)
+		}
+		if a.col4 != 2 || a.col8 != 4 || a.col12 != 6 {
+			t.Errorf("%v: have spans %v %v %v, want unchanged", lay, a.col4, a.col8, a.col12)
+		}
+	}
+}
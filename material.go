@@ -23,11 +23,19 @@ var (
 type Material struct {
 	Box
 
+	env   *Environment // set when added to environment
+	added int          // order added to environment
+
 	Drawer glutil.DrawerFunc
 
 	col4, col8, col12 int
 
-	hidden bool
+	hidden    bool
+	collapsed bool       // permanent surface without room to show
+	overlay   bool       // drawn without shadow, such as scrim and debug overlay
+	owner     *Material  // hidden with owner; see part
+	fade      float32    // fraction faded out; see opacity
+	offset    [2]float32 // from world where drawn and touched; see at
 
 	BehaviorFlags Behavior
 
@@ -35,11 +43,15 @@ type Material struct {
 		value      string
 		height     float32
 		r, g, b, a float32
+		set        bool    // color set, otherwise contrasts background
+		inset      float32 // from start of material
+		middle     bool    // centered vertically, otherwise at top
 	}
 
 	icon struct {
 		x, y       float32
 		r, g, b, a float32
+		set        bool
 	}
 
 	cr, cg, cb, ca float32 // color for uniform
@@ -47,25 +59,68 @@ type Material struct {
 	IsCircle  bool
 	Roundness float32
 
+	// Ink is touch feedback of material.
+	Ink   Ink
+	state State
+
+	// Role and Label describe material to assistive technologies. If Role is
+	// RoleNone, it's inferred from type and content; if Label is empty, text
+	// of material is used.
+	Role  Role
+	Label string
+
+	// PressedElevation, if greater than its resting elevation, raises material
+	// while pressed.
+	PressedElevation Elevation
+
+	lift     float32 // current elevation above resting elevation
+	raised   float32 // lift when not pressed, set by SetElevation
+	liftAnim *Handle
+
 	touch struct {
-		state touch.Type
-		x, y  float32
-		start time.Time
+		state      touch.Type
+		x, y       float32 // relative to material, in unit values
+		start, end time.Time
 	}
 
 	ShowImage bool
 	Rotate    float32 // Radian
 }
 
+// Span sets number of columns material spans on grids of 4, 8 and 12 columns.
+// A value of zero spans all columns.
 func (mtrl *Material) Span(col4, col8, col12 int) {
 	mtrl.col4, mtrl.col8, mtrl.col12 = col4, col8, col12
 }
 
+// SpanFor returns number of columns material spans on grid gd. Grids with
+// column counts other than 4, 8 or 12 use span of the nearest smaller count.
+func (mtrl *Material) SpanFor(gd *Grid) int {
+	n := mtrl.col12
+	if gd.Columns < 8 {
+		n = mtrl.col4
+	} else if gd.Columns < 12 {
+		n = mtrl.col8
+	}
+	if n <= 0 || n > gd.Columns {
+		n = gd.Columns
+	}
+	return n
+}
+
+// SpanColumns returns constraints placing material at column i, zero-based,
+// spanning columns set by Span for the environment's grid.
+func (mtrl *Material) SpanColumns(env *Environment, i int) []simplex.Constraint {
+	return mtrl.Columns(env.Box, env.Grid, i, mtrl.SpanFor(env.Grid))
+}
+
 func New(ctx gl.Context, color Color) *Material {
 	mtrl := &Material{
 		BehaviorFlags: DescriptorRaised,
+		Ink:           DefaultInk,
 	}
 	mtrl.icon.x, mtrl.icon.y = -1, -1
+	mtrl.icon.a, mtrl.text.a = 1, 1
 	mtrl.touch.state = touch.TypeEnd
 	mtrl.cr, mtrl.cg, mtrl.cb, mtrl.ca = color.RGBA()
 
@@ -83,10 +138,12 @@ func (mtrl *Material) SetIcon(ic icon.Icon) {
 
 func (mtrl *Material) SetIconColor(color Color) {
 	mtrl.icon.r, mtrl.icon.g, mtrl.icon.b, mtrl.icon.a = color.RGBA()
+	mtrl.icon.set = true
 }
 
 func (mtrl *Material) SetTextColor(color Color) {
 	mtrl.text.r, mtrl.text.g, mtrl.text.b, mtrl.text.a = color.RGBA()
+	mtrl.text.set = true
 }
 
 func (mtrl *Material) SetTextHeight(h float32) {
@@ -103,20 +160,57 @@ func (mtrl *Material) Bind(lpro *simplex.Program) {
 
 func (mtrl *Material) World() *f32.Mat4 { return &mtrl.world }
 
-func (mtrl *Material) Hidden() bool { return mtrl.hidden }
+func (mtrl *Material) Hidden() bool {
+	return mtrl.hidden || mtrl.owner != nil && mtrl.owner.Hidden()
+}
+
+// opacity returns factor of alpha material is drawn with, faded with owner.
+func (mtrl *Material) opacity() float32 {
+	op := 1 - mtrl.fade
+	if mtrl.owner != nil {
+		op *= mtrl.owner.opacity()
+	}
+	return op
+}
+
+// part reports whether sheet is a plain material drawn as part of another
+// sheet; touch events pass through parts to the sheets beneath.
+func part(sheet Sheet) bool {
+	_, ok := sheet.(*Material)
+	return ok && sheet.M().owner != nil
+}
+
+// Show makes material visible and receptive to touch events.
+func (mtrl *Material) Show() { mtrl.hidden = false }
+
+// Hide makes material invisible and ignores touch events.
+func (mtrl *Material) Hide() { mtrl.hidden = true }
 
 func (mtrl *Material) M() *Material { return mtrl }
 
 func (mtrl *Material) Contains(tx, ty float32) bool {
-	x, y, w, h := mtrl.world[0][3], mtrl.world[1][3], mtrl.world[0][0], mtrl.world[1][1]
+	x, y := mtrl.at()
+	w, h := mtrl.world[0][0], mtrl.world[1][1]
 	return x <= tx && tx <= x+w && y <= ty && ty <= y+h
 }
 
 func (mtrl *Material) RelativeCoords(tx, ty float32) (float32, float32) {
-	x, y, w, h := mtrl.world[0][3], mtrl.world[1][3], mtrl.world[0][0], mtrl.world[1][1]
+	x, y := mtrl.at()
+	w, h := mtrl.world[0][0], mtrl.world[1][1]
 	return (tx - x) / w, (ty - y) / h
 }
 
+// at returns position where material is drawn and touched; its world position
+// moved by offset of material and of its owners, such as a snackbar sliding in
+// without waiting for layout.
+func (mtrl *Material) at() (x, y float32) {
+	x, y = mtrl.world[0][3], mtrl.world[1][3]
+	for m := mtrl; m != nil; m = m.owner {
+		x, y = x+m.offset[0], y+m.offset[1]
+	}
+	return x, y
+}
+
 func (mtrl *Material) Constraints(env *Environment) []simplex.Constraint {
 	return nil
 }
@@ -128,6 +222,9 @@ type Button struct {
 	*Material
 	OnPress func()
 	OnTouch func(touch.Event)
+
+	textButton bool // flat and labeled with primary color, such as dialog actions
+	inverse    bool // text button labeled with accent color on inverse surface, such as snackbar actions
 }
 
 type FloatingActionButton struct {
@@ -139,14 +236,14 @@ type FloatingActionButton struct {
 
 func (fab *FloatingActionButton) Constraints(env *Environment) []simplex.Constraint {
 	var size float32
-	switch env.Grid.Columns {
-	case 4, 8:
+	switch {
+	case env.Grid.Columns < 12:
 		if fab.Mini {
 			size = Dp(40).Px()
 		} else {
 			size = Dp(56).Px()
 		}
-	case 12:
+	default:
 		if fab.Mini {
 			size = Dp(48).Px() // TODO size unconfirmed
 		} else {
@@ -154,7 +251,7 @@ func (fab *FloatingActionButton) Constraints(env *Environment) []simplex.Constra
 		}
 	}
 	fab.Roundness = size / 2 // TODO consider how this should work
-	return []simplex.Constraint{fab.Width(size), fab.Height(size), fab.Z(6)}
+	return []simplex.Constraint{fab.Width(size), fab.Height(size), fab.Elevate(ElevationFAB)}
 }
 
 // TODO https://www.google.com/design/spec/layout/structure.html#structure-toolbars
@@ -167,47 +264,42 @@ type Toolbar struct {
 
 func (bar *Toolbar) AddAction(btn *Button) {
 	btn.BehaviorFlags = DescriptorFlat
-	btn.SetIconColor(Black)
+	btn.restyle()
 	bar.actions = append(bar.actions, btn)
 }
 
 // TODO function breaks (index out of range) if there are no actions
 func (tb *Toolbar) Constraints(env *Environment) []simplex.Constraint {
-	stp := env.Grid.StepSize()
 	var (
-		width, height float32
-		btnsize       float32
-		titleStart    float32
+		height     float32
+		btnsize    float32
+		titleStart float32
 	)
 
-	switch env.Grid.Columns {
-	case 4:
-		width = float32(tb.col4) * stp
+	switch {
+	case env.Grid.Columns < 8:
 		height = Dp(56).Px()
 		btnsize = Dp(24).Px()
 		titleStart = Dp(48).Px()
-	case 8:
-		width = float32(tb.col8) * stp
+	case env.Grid.Columns < 12:
 		height = Dp(56).Px()
 		btnsize = Dp(24).Px()
 		titleStart = Dp(72).Px()
-	case 12:
-		width = float32(tb.col12) * stp
+	default:
 		height = Dp(64).Px()
 		btnsize = Dp(32).Px()
 		titleStart = Dp(72).Px()
 	}
 	nav := tb.Nav
 	title := tb.Title
-	cns := []simplex.Constraint{
-		tb.Width(width), tb.Height(height), tb.Z(4),
-		tb.StartIn(env.Box, env.Grid.Margin), tb.TopIn(env.Box, env.Grid.Margin),
+	cns := append(tb.SpanColumns(env, 0),
+		tb.Height(height), tb.Elevate(ElevationAppBar), tb.TopIn(env.Box, env.Grid.Margin),
 		nav.Width(btnsize), nav.Height(btnsize), nav.Z(5),
 		nav.StartIn(tb.Box, env.Grid.Gutter),
 		nav.CenterVerticalIn(tb.Box),
 		title.StartIn(tb.Box, titleStart), title.Before(tb.actions[len(tb.actions)-1].Box, 0), // breaks here
 		title.CenterVerticalIn(tb.Box), title.Height(btnsize), title.Z(5),
-	}
+	)
 
 	for i, btn := range tb.actions {
 		cns = append(cns, btn.Width(btnsize), btn.Height(btnsize), btn.Z(5), btn.CenterVerticalIn(tb.Box))
@@ -229,22 +321,46 @@ type Menu struct {
 	*Material
 	selected int
 	actions  []*Button
+
+	shown   float32 // fraction slid down from layout
+	closing bool    // sliding up
+	anim    *Handle
 }
 
 func (mu *Menu) AddAction(btn *Button) {
 	btn.BehaviorFlags = DescriptorFlat
-	btn.SetIconColor(Black)
-	btn.hidden = mu.hidden
+	btn.restyle()
+	btn.hidden = mu.hidden || mu.closing
+	btn.offset = mu.offset
+	btn.Role = RoleMenuItem
 	mu.actions = append(mu.actions, btn)
 }
 
 // currently the menu buttons don't act like a single object with the menu,
-// *.hidden doesn't do anything visually, only changes whether button is
-// clickable (see environment.go)
+// *.hidden skips drawing and touch handling of each button (see environment.go)
+// and buttons are offset along with the menu (see slide).
 // A better design would be adding the menu and its buttons to a surface that
 // moves as one and preserves the relative locations of all its components to a
 // given point on the surface
 
+// animate schedules anim with environment of material, or plays it to its end
+// immediately and returns nil if material has no environment.
+func (mtrl *Material) animate(anim Animation) *Handle {
+	if mtrl.env != nil {
+		return mtrl.env.Animate(anim)
+	}
+	if anim.Start != nil {
+		anim.Start()
+	}
+	if anim.Interp != nil {
+		anim.Interp(1)
+	}
+	if anim.End != nil {
+		anim.End()
+	}
+	return nil
+}
+
 func (mu *Menu) ShowAt(m *f32.Mat4) {
 	x := mu.Box.world[0][3]
 	y := mu.Box.world[1][3]
@@ -259,69 +375,70 @@ func (mu *Menu) ShowAt(m *f32.Mat4) {
 	mu.Show()
 }
 
+// Show slides menu and its actions down by its height from where laid out.
 func (mu *Menu) Show() {
-	if mu.hidden {
-		go func() {
-			mw := mu.Box.world
-			h := mu.Box.world[1][1]
-			y := mu.Box.world[1][3]
-			anim := Animation{
-				Sig: ExpSig,
-				Dur: 300 * time.Millisecond,
-				Start: func() {
-					mu.hidden = false
-					for _, btn := range mu.actions {
-						btn.hidden = false
-					}
-				},
-				Interp: func(dt float32) {
-					mu.Box.world[1][3] = (mw[1][3] + mw[1][1]) - mw[1][1]*dt
-				},
-				End: func() {
-					mu.Box.world[1][1] = h
-					mu.Box.world[1][3] = y - h
-					for _, btn := range mu.actions {
-						btn.Box.world[1][3] -= h
-					}
-				},
-			}
-			anim.Do()
-		}()
+	if !mu.hidden && !mu.closing {
+		return
 	}
+	mu.hidden, mu.closing = false, false
+	for _, btn := range mu.actions {
+		btn.hidden = false
+	}
+	mu.slide(1, Decelerate, 300*time.Millisecond, nil)
 }
 
+// Hide slides menu back up to where laid out; menu is drawn until slid and
+// its actions are hidden immediately.
 func (mu *Menu) Hide() {
-	if !mu.hidden {
-		h := mu.Box.world[1][1]
-		y := mu.Box.world[1][3]
-		mw := mu.Box.world
-		Animation{
-			Sig: ExpSig,
-			Dur: 100 * time.Millisecond,
-			Start: func() {
-				mu.hidden = true
-				for _, btn := range mu.actions {
-					btn.hidden = true
-				}
-			},
-			Interp: func(dt float32) {
-				mu.Box.world[1][3] = (mw[1][3] + mw[1][1]) - mw[1][1]*(1-dt)
-			},
-			End: func() {
-				mu.Box.world[1][1] = h
-				mu.Box.world[1][3] = h + y
-				for _, btn := range mu.actions {
-					btn.Box.world[1][3] += h
-				}
-			},
-		}.Do()
+	if mu.hidden || mu.closing {
+		return
+	}
+	mu.closing = true
+	for _, btn := range mu.actions {
+		btn.hidden = true
+	}
+	mu.slide(0, Accelerate, 100*time.Millisecond, func() {
+		if mu.closing {
+			mu.hidden, mu.closing = true, false
+		}
+	})
+}
+
+// slide animates fraction of menu shown, cancelling any slide in progress.
+// Menu and actions are offset from their layout, so sliding again part way
+// or laying out again doesn't move them further.
+func (mu *Menu) slide(shown float32, sig Curve, dur time.Duration, end func()) {
+	if mu.anim != nil {
+		mu.anim.Cancel()
+	}
+	from := mu.shown
+	mu.anim = mu.animate(Animation{
+		Sig: sig,
+		Dur: dur,
+		Start: func() {
+			from = mu.shown
+		},
+		Interp: func(dt float32) {
+			mu.shown = from + (shown-from)*dt
+			mu.place()
+		},
+		End: end,
+	})
+}
+
+// place offsets menu and actions down from their layout by the fraction of
+// the height of menu shown.
+func (mu *Menu) place() {
+	mu.offset[1] = -mu.shown * mu.world[1][1]
+	for _, btn := range mu.actions {
+		btn.offset = mu.offset
 	}
 }
 
 // TODO function breaks (index out of range) if there are no actions
 func (mu *Menu) Constraints(env *Environment) []simplex.Constraint {
 	cns := []simplex.Constraint{
-		mu.Width(Dp(100).Px()), mu.Z(8),
+		mu.Width(Dp(100).Px()), mu.Elevate(ElevationMenu),
 		mu.StartIn(env.Box, env.Grid.Margin), mu.Above(env.Box, env.Grid.Margin),
 	}
 
@@ -0,0 +1,303 @@
+package material
+
+import (
+	"time"
+
+	"github.com/dskinner/simplex"
+	"golang.org/x/mobile/event/touch"
+)
+
+// SelectionDur is duration of selection controls animating between checked
+// and unchecked.
+var SelectionDur = 150 * time.Millisecond
+
+// partZ is elevation of a part above the sheet it's drawn in, ordering parts
+// without raising them noticeably.
+const partZ = 0.01
+
+// partIn returns constraints sizing part to w, h centered in b, partZ above it.
+func partIn(part *Material, b Box, w, h float32) []simplex.Constraint {
+	return []simplex.Constraint{
+		part.Width(w), part.Height(h),
+		part.CenterHorizontalIn(b), part.CenterVerticalIn(b),
+		part.ElevateAbove(b, partZ),
+	}
+}
+
+// follow returns animation moving part from where it starts to the position
+// and size returned by to. Targets are read every frame, so layouts before or
+// while animating are followed.
+func follow(part *Material, sig Curve, to func() (x, y, w, h float32)) Animation {
+	var from [4]float32
+	return Animation{
+		Sig: sig,
+		Dur: SelectionDur,
+		Start: func() {
+			w := &part.world
+			from = [4]float32{w[0][3], w[1][3], w[0][0], w[1][1]}
+		},
+		Interp: func(dt float32) {
+			x, y, sw, sh := to()
+			w := &part.world
+			w[0][3] = from[0] + (x-from[0])*dt
+			w[1][3] = from[1] + (y-from[1])*dt
+			w[0][0] = from[2] + (sw-from[2])*dt
+			w[1][1] = from[3] + (sh-from[3])*dt
+		},
+	}
+}
+
+// scaleIn returns animation sizing part to s, s centered in outline.
+func scaleIn(part, outline *Material, s float32, sig Curve) Animation {
+	return follow(part, sig, func() (x, y, w, h float32) {
+		b := &outline.world
+		return b[0][3] + (b[0][0]-s)/2, b[1][3] + (b[1][1]-s)/2, s, s
+	})
+}
+
+// theme returns theme of environment material belongs to.
+func (mtrl *Material) theme() Theme {
+	if mtrl.env == nil {
+		return DefaultTheme
+	}
+	return mtrl.env.Theme()
+}
+
+// color returns background color of material.
+func (mtrl *Material) color() Color {
+	return colorOf(mtrl.cr, mtrl.cg, mtrl.cb, mtrl.ca)
+}
+
+// Checked reports whether material is selected, such as a checked checkbox.
+func (mtrl *Material) Checked() bool { return mtrl.state&StateSelected != 0 }
+
+// Disabled reports whether material ignores touch events.
+func (mtrl *Material) Disabled() bool { return mtrl.state&StateDisabled != 0 }
+
+// restart cancels animation of handle h, if any, and starts anim in its place.
+func (mtrl *Material) restart(h **Handle, anim Animation) {
+	if *h != nil {
+		(*h).Cancel()
+	}
+	*h = mtrl.env.Animate(anim)
+}
+
+// Checkbox is a selection control toggled between checked and unchecked.
+// https://material.io/guidelines/components/selection-controls.html#selection-controls-checkbox
+type Checkbox struct {
+	*Material
+	OnChange func(checked bool)
+
+	box, mark *Material // outline, and fill with check scaled in when checked
+	anim      *Handle
+}
+
+// Constraints sizes checkbox to a 48dp touch target with its box centered.
+func (cb *Checkbox) Constraints(env *Environment) []simplex.Constraint {
+	var s float32
+	if cb.Checked() {
+		s = Dp(18).Px()
+	}
+	cns := []simplex.Constraint{cb.Width(Dp(48).Px()), cb.Height(Dp(48).Px())}
+	cns = append(cns, partIn(cb.box, cb.Box, Dp(24).Px(), Dp(24).Px())...)
+	return append(cns, partIn(cb.mark, cb.box.Box, s, s)...)
+}
+
+// SetChecked checks or unchecks checkbox, animating its box. OnChange is not
+// called.
+func (cb *Checkbox) SetChecked(checked bool) {
+	if checked == cb.Checked() {
+		return
+	}
+	cb.SetState(StateSelected, checked)
+	cb.ApplyTheme(cb.theme())
+	if checked {
+		cb.restart(&cb.anim, scaleIn(cb.mark, cb.box, Dp(18).Px(), Decelerate))
+	} else {
+		cb.restart(&cb.anim, scaleIn(cb.mark, cb.box, 0, Accelerate))
+	}
+}
+
+// SetDisabled disables or enables checkbox.
+func (cb *Checkbox) SetDisabled(disabled bool) {
+	cb.SetState(StateDisabled, disabled)
+	cb.ApplyTheme(cb.theme())
+}
+
+func (cb *Checkbox) toggle() {
+	cb.SetChecked(!cb.Checked())
+	if cb.OnChange != nil {
+		cb.OnChange(cb.Checked())
+	}
+}
+
+// RadioGroup is a set of radio buttons of which at most one is checked.
+type RadioGroup struct {
+	OnChange func(selected *RadioButton)
+	buttons  []*RadioButton
+}
+
+// Selected returns checked radio button of group, or nil if none is.
+func (grp *RadioGroup) Selected() *RadioButton {
+	for _, rb := range grp.buttons {
+		if rb.Checked() {
+			return rb
+		}
+	}
+	return nil
+}
+
+// Select checks rb and unchecks all other radio buttons of group. If rb is
+// nil, all are unchecked. OnChange callbacks are not called.
+func (grp *RadioGroup) Select(rb *RadioButton) {
+	for _, x := range grp.buttons {
+		if x != rb {
+			x.setChecked(false)
+		}
+	}
+	if rb != nil {
+		rb.setChecked(true)
+	}
+}
+
+// RadioButton is a selection control checked to select one option of its group.
+// https://material.io/guidelines/components/selection-controls.html#selection-controls-radio-button
+type RadioButton struct {
+	*Material
+	OnChange func(checked bool)
+
+	grp       *RadioGroup
+	ring, dot *Material // outline, and fill scaled in when checked
+	anim      *Handle
+}
+
+// Group returns radio group of rb.
+func (rb *RadioButton) Group() *RadioGroup { return rb.grp }
+
+// Constraints sizes radio button to a 48dp touch target with its ring centered.
+func (rb *RadioButton) Constraints(env *Environment) []simplex.Constraint {
+	var s float32
+	if rb.Checked() {
+		s = Dp(10).Px()
+	}
+	cns := []simplex.Constraint{rb.Width(Dp(48).Px()), rb.Height(Dp(48).Px())}
+	cns = append(cns, partIn(rb.ring, rb.Box, Dp(24).Px(), Dp(24).Px())...)
+	return append(cns, partIn(rb.dot, rb.ring.Box, s, s)...)
+}
+
+// SetChecked checks radio button, unchecking others of its group, or unchecks
+// it. OnChange is not called.
+func (rb *RadioButton) SetChecked(checked bool) {
+	switch {
+	case checked:
+		rb.grp.Select(rb)
+	case rb.Checked():
+		rb.grp.Select(nil)
+	}
+}
+
+func (rb *RadioButton) setChecked(checked bool) {
+	if checked == rb.Checked() {
+		return
+	}
+	rb.SetState(StateSelected, checked)
+	rb.ApplyTheme(rb.theme())
+	if checked {
+		rb.restart(&rb.anim, scaleIn(rb.dot, rb.ring, Dp(10).Px(), Decelerate))
+	} else {
+		rb.restart(&rb.anim, scaleIn(rb.dot, rb.ring, 0, Accelerate))
+	}
+}
+
+// SetDisabled disables or enables radio button.
+func (rb *RadioButton) SetDisabled(disabled bool) {
+	rb.SetState(StateDisabled, disabled)
+	rb.ApplyTheme(rb.theme())
+}
+
+// check selects radio button if not already, calling OnChange of buttons
+// changed and of group.
+func (rb *RadioButton) check() {
+	if rb.Checked() {
+		return
+	}
+	prev := rb.grp.Selected()
+	rb.grp.Select(rb)
+	if prev != nil && prev.OnChange != nil {
+		prev.OnChange(false)
+	}
+	if rb.OnChange != nil {
+		rb.OnChange(true)
+	}
+	if rb.grp.OnChange != nil {
+		rb.grp.OnChange(rb)
+	}
+}
+
+// Switch is a selection control toggling a single option on or off.
+// https://material.io/guidelines/components/selection-controls.html#selection-controls-switch
+type Switch struct {
+	*Material
+	OnChange func(on bool)
+
+	track, thumb *Material
+	anim         *Handle
+}
+
+// thumbStart returns start of thumb relative to switch.
+func (sw *Switch) thumbStart() float32 {
+	if sw.Checked() {
+		return Dp(29).Px()
+	}
+	return Dp(9).Px()
+}
+
+// Constraints sizes switch to a 48dp high touch target with its track
+// centered and its thumb at the end of track if on, else the start.
+func (sw *Switch) Constraints(env *Environment) []simplex.Constraint {
+	cns := []simplex.Constraint{sw.Width(Dp(58).Px()), sw.Height(Dp(48).Px())}
+	cns = append(cns, partIn(sw.track, sw.Box, Dp(34).Px(), Dp(14).Px())...)
+	return append(cns,
+		sw.thumb.Width(Dp(20).Px()), sw.thumb.Height(Dp(20).Px()),
+		sw.thumb.CenterVerticalIn(sw.Box),
+		simplex.Constrain(simplex.Coef{1, sw.thumb.l}, simplex.Coef{-1, sw.l}).Equal(float64(sw.thumbStart())),
+		sw.thumb.ElevateAbove(sw.Box, float32(ElevationSwitch)),
+	)
+}
+
+// SetChecked turns switch on or off, sliding its thumb. OnChange is not called.
+func (sw *Switch) SetChecked(on bool) {
+	if on == sw.Checked() {
+		return
+	}
+	track, thumb := sw.track.color(), sw.thumb.color()
+	sw.SetState(StateSelected, on)
+	sw.ApplyTheme(sw.theme())
+	trackTw := NewTween(sw.track, SelectionDur).Color(sw.track.color())
+	thumbTw := NewTween(sw.thumb, SelectionDur).Color(sw.thumb.color())
+	slide := follow(sw.thumb, Standard, func() (x, y, w, h float32) {
+		s := Dp(20).Px()
+		return sw.world[0][3] + sw.thumbStart(), sw.world[1][3] + (sw.world[1][1]-s)/2, s, s
+	})
+	sw.track.SetColor(track)
+	sw.thumb.SetColor(thumb)
+	sw.restart(&sw.anim, Parallel(trackTw.Animation(), thumbTw.Animation(), slide))
+}
+
+// SetDisabled disables or enables switch.
+func (sw *Switch) SetDisabled(disabled bool) {
+	sw.SetState(StateDisabled, disabled)
+	sw.ApplyTheme(sw.theme())
+}
+
+// press shows ripple of switch around its thumb.
+func (sw *Switch) press(typ touch.Type, now time.Time) {
+	sw.thumb.press(typ, 0.5, 0.5, now)
+}
+
+func (sw *Switch) toggle() {
+	sw.SetChecked(!sw.Checked())
+	if sw.OnChange != nil {
+		sw.OnChange(sw.Checked())
+	}
+}
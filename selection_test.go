@@ -0,0 +1,144 @@
+package material
+
+import (
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+	"golang.org/x/mobile/event/touch"
+)
+
+func TestRadioGroup(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := &Environment{Clock: new(testClock)}
+	grp := new(RadioGroup)
+	a, b := env.NewRadioButton(nil, grp), env.NewRadioButton(nil, grp)
+
+	var changes []bool
+	a.OnChange = func(checked bool) { changes = append(changes, checked) }
+	var selected *RadioButton
+	grp.OnChange = func(rb *RadioButton) { selected = rb }
+
+	a.check()
+	b.check()
+	b.check()
+	if grp.Selected() != b || a.Checked() || selected != b {
+		t.Errorf("have a %v, b %v checked, want b only", a.Checked(), b.Checked())
+	}
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("have changes %v, want [true false]", changes)
+	}
+
+	b.SetChecked(false)
+	if grp.Selected() != nil {
+		t.Errorf("have selected radio button after unchecking")
+	}
+}
+
+func TestCheckboxToggle(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1, HeightPx: 100}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	cb := env.NewCheckbox(nil)
+	cb.world[0][0], cb.world[1][1] = 48, 48
+	cb.box.world[0][3], cb.box.world[1][3] = 12, 12
+	cb.box.world[0][0], cb.box.world[1][1] = 24, 24
+
+	var checked bool
+	cb.OnChange = func(c bool) { checked = c }
+	ev := touch.Event{X: 24, Y: 100 - 24}
+	for _, typ := range []touch.Type{touch.TypeBegin, touch.TypeEnd} {
+		ev.Type = typ
+		if !env.Touch(ev) {
+			t.Fatalf("touch %v not handled", typ)
+		}
+	}
+	if !cb.Checked() || !checked {
+		t.Fatalf("checkbox not checked by touch")
+	}
+
+	env.Scheduler().Step()
+	clock.Add(SelectionDur)
+	env.Scheduler().Step()
+	if w, x := cb.mark.world[0][0], cb.mark.world[0][3]; w != 18 || x != 15 {
+		t.Errorf("have mark width %v at %v, want 18 at 15", w, x)
+	}
+
+	// touch beginning outside checkbox doesn't toggle it when released over it.
+	env.Touch(touch.Event{X: 90, Y: 100 - 90, Type: touch.TypeBegin})
+	ev.Type = touch.TypeEnd
+	env.Touch(ev)
+	if !cb.Checked() {
+		t.Errorf("checkbox toggled by touch released over it")
+	}
+
+	cb.SetDisabled(true)
+	ev.Type = touch.TypeBegin
+	env.Touch(ev)
+	ev.Type = touch.TypeEnd
+	env.Touch(ev)
+	if !cb.Checked() {
+		t.Errorf("disabled checkbox toggled by touch")
+	}
+}
+
+func TestSwitchSetChecked(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	sw := env.NewSwitch(nil)
+	sw.world[0][3] = 100
+
+	sw.SetChecked(true)
+	env.Scheduler().Step()
+	clock.Add(SelectionDur)
+	env.Scheduler().Step()
+	if x := sw.thumb.world[0][3]; x != 129 {
+		t.Errorf("have thumb at %v, want 129", x)
+	}
+	if c := sw.thumb.color(); c != DefaultTheme.Accent {
+		t.Errorf("have thumb color %v, want %v", c, DefaultTheme.Accent)
+	}
+}
+
+func TestSetCheckedBeforeLayout(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	cb := env.NewCheckbox(nil)
+	sw := env.NewSwitch(nil)
+
+	// as restoring state before first layout.
+	cb.SetChecked(true)
+	sw.SetChecked(true)
+	cb.box.world[0][3], cb.box.world[1][3] = 112, 112
+	cb.box.world[0][0], cb.box.world[1][1] = 24, 24
+	sw.world[0][3], sw.world[1][3] = 100, 200
+	sw.world[0][0], sw.world[1][1] = 58, 48
+
+	env.Scheduler().Step()
+	clock.Add(SelectionDur / 2)
+	env.Scheduler().Step()
+	cb.box.world[0][3] = 212 // laid out again while animating
+	clock.Add(SelectionDur / 2)
+	env.Scheduler().Step()
+	if x, y, w := cb.mark.world[0][3], cb.mark.world[1][3], cb.mark.world[0][0]; x != 215 || y != 115 || w != 18 {
+		t.Errorf("have mark width %v at %v, %v; want 18 at 215, 115", w, x, y)
+	}
+	if x, y := sw.thumb.world[0][3], sw.thumb.world[1][3]; x != 129 || y != 214 {
+		t.Errorf("have thumb at %v, %v; want 129, 214", x, y)
+	}
+}
+
+func TestSelectionPartsHidden(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	cb := env.NewCheckbox(nil)
+	cb.Hide()
+	if !cb.box.Hidden() || !cb.mark.Hidden() {
+		t.Errorf("parts of hidden checkbox not hidden")
+	}
+	cb.Show()
+	if cb.box.Hidden() {
+		t.Errorf("parts of shown checkbox hidden")
+	}
+}
@@ -0,0 +1,280 @@
+package material
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/dskinner/simplex"
+	"golang.org/x/mobile/event/key"
+	"golang.org/x/mobile/event/touch"
+)
+
+// maxTicks is the most tick marks shown by discrete sliders; sliders with
+// more steps are shown without tick marks.
+const maxTicks = 50
+
+// Slider selects a value, or a range of values for range sliders, along a
+// track from a minimum to a maximum. Continuous sliders select any value
+// between, discrete sliders snap values to steps marked by ticks.
+// https://material.io/guidelines/components/sliders.html
+type Slider struct {
+	*Material
+
+	// OnChange is called with selected range when changed by touch or keys;
+	// lo is the minimum of slider unless it's a range slider.
+	OnChange func(lo, hi float64)
+
+	// Format returns text of value label; values are formatted with the
+	// fewest digits necessary if nil.
+	Format func(v float64) string
+
+	// ValueLabel shows value of thumb in a bubble above it while dragged.
+	ValueLabel bool
+
+	min, max, step float64
+	vals           [2]float64 // lo, hi
+	active         int        // index of thumb last dragged
+
+	track, fill, label *Material
+	thumbs             [2]*Material // lo thumb is nil unless range slider
+	ticks              []*Material
+}
+
+// Bounds returns minimum, maximum and step of slider.
+func (sl *Slider) Bounds() (min, max, step float64) {
+	return sl.min, sl.max, sl.step
+}
+
+// SetBounds sets minimum, maximum and step of slider; slider is continuous if
+// step is zero. Values are snapped to new bounds and tick marks are laid out
+// with next layout.
+func (sl *Slider) SetBounds(min, max, step float64) {
+	sl.min, sl.max, sl.step = min, max, math.Abs(step)
+	n := 0
+	if sl.step > 0 {
+		n = int(math.Ceil((max-min)/sl.step)) + 1
+	}
+	if n > maxTicks {
+		n = 0
+	}
+	for len(sl.ticks) < n {
+		tick := sl.env.newPart(nil, sl.Material)
+		tick.BehaviorFlags = DescriptorRaised
+		tick.overlay = true
+		tick.IsCircle = true
+		sl.ticks = append(sl.ticks, tick)
+	}
+	for i, tick := range sl.ticks {
+		tick.hidden = i >= n
+	}
+	sl.set(sl.vals[0], sl.vals[1])
+}
+
+// Value returns value of slider; the upper value of range sliders.
+func (sl *Slider) Value() float64 { return sl.vals[1] }
+
+// SetValue sets value of slider, or upper value of range slider. OnChange is
+// not called.
+func (sl *Slider) SetValue(v float64) { sl.set(sl.vals[0], v) }
+
+// Range returns selected range of slider; lo is the minimum of slider unless
+// it's a range slider.
+func (sl *Slider) Range() (lo, hi float64) { return sl.vals[0], sl.vals[1] }
+
+// SetRange sets selected range of range slider. OnChange is not called.
+func (sl *Slider) SetRange(lo, hi float64) {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	sl.set(lo, hi)
+}
+
+// SetDisabled disables or enables slider.
+func (sl *Slider) SetDisabled(disabled bool) {
+	sl.SetState(StateDisabled, disabled)
+	sl.ApplyTheme(sl.theme())
+}
+
+// snap returns v clamped to bounds of slider and rounded to nearest step.
+func (sl *Slider) snap(v float64) float64 {
+	if sl.step > 0 {
+		v = sl.min + math.Round((v-sl.min)/sl.step)*sl.step
+	}
+	return math.Max(sl.min, math.Min(sl.max, v))
+}
+
+// fraction returns distance of v along slider from 0 at minimum to 1 at maximum.
+func (sl *Slider) fraction(v float64) float64 {
+	if sl.max <= sl.min {
+		return 0
+	}
+	return (v - sl.min) / (sl.max - sl.min)
+}
+
+func (sl *Slider) format(v float64) string {
+	if sl.Format != nil {
+		return sl.Format(v)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// set snaps and sets selected range of slider, reporting whether it changed.
+func (sl *Slider) set(lo, hi float64) bool {
+	if sl.thumbs[0] == nil {
+		lo = sl.min
+	}
+	lo, hi = sl.snap(lo), sl.snap(hi)
+	changed := lo != sl.vals[0] || hi != sl.vals[1]
+	sl.vals = [2]float64{lo, hi}
+	sl.place()
+	return changed
+}
+
+// move sets value of active thumb to v without passing the other thumb, and
+// calls OnChange if changed.
+func (sl *Slider) move(v float64) {
+	lo, hi := sl.vals[0], sl.vals[1]
+	if sl.active == 0 {
+		lo = math.Min(v, hi)
+	} else {
+		hi = math.Max(v, lo)
+	}
+	if sl.set(lo, hi) && sl.OnChange != nil {
+		sl.OnChange(sl.vals[0], sl.vals[1])
+	}
+}
+
+// place moves fill, thumbs and value label of slider to its values along the
+// current track, without waiting for layout.
+func (sl *Slider) place() {
+	tx, tw := sl.track.world[0][3], sl.track.world[0][0]
+	x := func(v float64) float32 { return tx + tw*float32(sl.fraction(v)) }
+
+	lo, hi := x(sl.vals[0]), x(sl.vals[1])
+	sl.fill.world[0][3], sl.fill.world[0][0] = lo, hi-lo
+	focused := sl.state&StateFocused != 0
+	for i, thumb := range sl.thumbs {
+		if thumb != nil {
+			thumb.world[0][3] = x(sl.vals[i]) - thumb.world[0][0]/2
+			thumb.SetState(StateFocused, focused && i == sl.active)
+		}
+	}
+	sl.label.world[0][3] = x(sl.vals[sl.active]) - sl.label.world[0][0]/2
+	sl.label.SetText(sl.format(sl.vals[sl.active]))
+	sl.colorTicks(sl.theme())
+}
+
+// centerAt returns constraint centering a horizontally at fraction f of b.
+func centerAt(a, b Box, f float64) simplex.Constraint {
+	return simplex.Constrain(simplex.Coef{0.5, a.l}, simplex.Coef{0.5, a.r}, simplex.Coef{f - 1, b.l}, simplex.Coef{-f, b.r}).Equal(0)
+}
+
+// Constraints sizes slider to a 48dp high touch target with its track inset by
+// the radius of thumbs and parts placed along it by value.
+func (sl *Slider) Constraints(env *Environment) []simplex.Constraint {
+	var (
+		r     = Dp(10).Px() // radius of thumb
+		track = sl.track
+		fill  = sl.fill
+		label = sl.label
+	)
+	cns := []simplex.Constraint{
+		sl.Height(Dp(48).Px()),
+		track.Height(Dp(4).Px()), track.CenterVerticalIn(sl.Box), track.ElevateAbove(sl.Box, partZ),
+		simplex.Constrain(simplex.Coef{1, track.l}, simplex.Coef{-1, sl.l}).Equal(float64(r)),
+		simplex.Constrain(simplex.Coef{1, sl.r}, simplex.Coef{-1, track.r}).Equal(float64(r)),
+
+		fill.Height(Dp(4).Px()), fill.CenterVerticalIn(track.Box), fill.ElevateAbove(track.Box, partZ),
+		simplex.Constrain(simplex.Coef{1, fill.l}, simplex.Coef{sl.fraction(sl.vals[0]) - 1, track.l}, simplex.Coef{-sl.fraction(sl.vals[0]), track.r}).Equal(0),
+		simplex.Constrain(simplex.Coef{1, fill.r}, simplex.Coef{sl.fraction(sl.vals[1]) - 1, track.l}, simplex.Coef{-sl.fraction(sl.vals[1]), track.r}).Equal(0),
+
+		label.Width(Dp(32).Px()), label.Height(Dp(32).Px()), label.ElevateAbove(sl.Box, 5*partZ),
+		simplex.Constrain(simplex.Coef{1, label.b}, simplex.Coef{-1, sl.t}).Equal(0),
+		centerAt(label.Box, track.Box, sl.fraction(sl.vals[sl.active])),
+	}
+	for i, thumb := range sl.thumbs {
+		if thumb != nil {
+			cns = append(cns,
+				thumb.Width(2*r), thumb.Height(2*r),
+				thumb.CenterVerticalIn(track.Box), thumb.ElevateAbove(sl.Box, 4*partZ),
+				centerAt(thumb.Box, track.Box, sl.fraction(sl.vals[i])),
+			)
+		}
+	}
+	for i, tick := range sl.ticks {
+		v := math.Min(sl.max, sl.min+float64(i)*sl.step)
+		cns = append(cns,
+			tick.Width(Dp(2).Px()), tick.Height(Dp(2).Px()),
+			tick.CenterVerticalIn(track.Box), tick.ElevateAbove(sl.Box, 3*partZ),
+			centerAt(tick.Box, track.Box, sl.fraction(v)),
+		)
+	}
+	return cns
+}
+
+// drag moves thumb nearest the start of a touch to x in pixels as the touch
+// moves, showing value label until released.
+func (sl *Slider) drag(typ touch.Type, x float32, now time.Time) {
+	tx, tw := sl.track.world[0][3], sl.track.world[0][0]
+	var f float64
+	if tw > 0 {
+		f = math.Max(0, math.Min(1, float64((x-tx)/tw)))
+	}
+	v := sl.min + f*(sl.max-sl.min)
+
+	if typ == touch.TypeBegin {
+		sl.active = 1
+		if lo, hi := sl.vals[0], sl.vals[1]; sl.thumbs[0] != nil && (v < lo || v-lo < hi-v) {
+			sl.active = 0
+		}
+	}
+	sl.thumbs[sl.active].press(typ, 0.5, 0.5, now)
+	sl.label.hidden = !sl.ValueLabel || typ == touch.TypeEnd
+	if typ == touch.TypeEnd {
+		sl.SetState(StatePressed, false)
+	}
+	sl.move(v)
+}
+
+// key moves active thumb of slider by a step for arrow keys, by a tenth of
+// its range for page keys and to its bounds for home and end keys. Tab moves
+// between thumbs of range sliders.
+func (sl *Slider) key(ev key.Event) bool {
+	if ev.Direction == key.DirRelease {
+		return false
+	}
+	step := sl.step
+	if step == 0 {
+		step = (sl.max - sl.min) / 100
+	}
+	page := math.Max(step, (sl.max-sl.min)/10)
+
+	v := sl.vals[sl.active]
+	switch ev.Code {
+	case key.CodeRightArrow, key.CodeUpArrow:
+		v += step
+	case key.CodeLeftArrow, key.CodeDownArrow:
+		v -= step
+	case key.CodePageUp:
+		v += page
+	case key.CodePageDown:
+		v -= page
+	case key.CodeHome:
+		v = sl.min
+	case key.CodeEnd:
+		v = sl.max
+	case key.CodeTab:
+		back := ev.Modifiers&key.ModShift != 0
+		if sl.thumbs[0] == nil || back == (sl.active == 0) {
+			return false
+		}
+		sl.active = 1 - sl.active
+		sl.place()
+		return true
+	default:
+		return false
+	}
+	sl.move(v)
+	return true
+}
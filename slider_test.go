@@ -0,0 +1,97 @@
+package material
+
+import (
+	"testing"
+
+	"golang.org/x/mobile/event/key"
+	"golang.org/x/mobile/event/size"
+	"golang.org/x/mobile/event/touch"
+)
+
+func TestSliderDiscrete(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	sl := env.NewSlider(nil)
+	sl.SetBounds(0, 10, 2)
+
+	for _, tc := range []struct{ in, want float64 }{
+		{-1, 0}, {2.9, 2}, {3.1, 4}, {11, 10},
+	} {
+		sl.SetValue(tc.in)
+		if v := sl.Value(); v != tc.want {
+			t.Errorf("SetValue(%v): have %v, want %v", tc.in, v, tc.want)
+		}
+	}
+	if n := len(sl.ticks); n != 6 {
+		t.Errorf("have %v ticks, want 6", n)
+	}
+	if lo, _ := sl.Range(); lo != 0 {
+		t.Errorf("have lo %v, want minimum", lo)
+	}
+
+	sl.SetBounds(0, 1000, 1)
+	for _, tick := range sl.ticks {
+		if !tick.Hidden() {
+			t.Fatal("ticks shown for too many steps")
+		}
+	}
+}
+
+func TestSliderKeys(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	sl := env.NewRangeSlider(nil)
+	sl.SetBounds(0, 100, 1)
+	sl.SetRange(20, 40)
+
+	var changes int
+	sl.OnChange = func(lo, hi float64) { changes++ }
+	press := func(code key.Code, mods key.Modifiers) bool {
+		return env.Key(key.Event{Code: code, Modifiers: mods, Direction: key.DirPress})
+	}
+
+	if press(key.CodeRightArrow, 0) {
+		t.Fatal("key handled without focus")
+	}
+	env.Focus(sl)
+	press(key.CodeRightArrow, 0)
+	press(key.CodePageUp, 0)
+	if _, hi := sl.Range(); hi != 51 {
+		t.Errorf("have hi %v, want 51", hi)
+	}
+	press(key.CodeTab, key.ModShift)
+	press(key.CodeEnd, 0)
+	if lo, hi := sl.Range(); lo != 51 || hi != 51 {
+		t.Errorf("have range %v, %v; want lo to stop at hi 51", lo, hi)
+	}
+	if changes != 3 {
+		t.Errorf("have %v changes, want 3", changes)
+	}
+}
+
+func TestSliderDrag(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1, HeightPx: 100}
+	env := &Environment{Clock: new(testClock)}
+	sl := env.NewSlider(nil)
+	sl.world[0][3], sl.world[0][0], sl.world[1][1] = 0, 120, 48
+	sl.track.world[0][3], sl.track.world[0][0] = 10, 100
+
+	var value float64
+	sl.OnChange = func(lo, hi float64) { value = hi }
+	for _, ev := range []touch.Event{
+		{X: 35, Y: 76, Type: touch.TypeBegin},
+		{X: 60, Y: 20, Type: touch.TypeMove}, // outside bounds of slider
+		{X: 500, Y: 20, Type: touch.TypeEnd},
+	} {
+		env.Touch(ev)
+		if ev.Type == touch.TypeMove && (value != 0.5 || sl.label.Hidden()) {
+			t.Errorf("have value %v while dragged, want 0.5 with label", value)
+		}
+	}
+	if value != 1 || !sl.label.Hidden() {
+		t.Errorf("have value %v when released, want 1 without label", value)
+	}
+	if env.Focused() != Sheet(sl) {
+		t.Errorf("slider not focused by touch")
+	}
+}
@@ -0,0 +1,267 @@
+package material
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dskinner/simplex"
+	"golang.org/x/mobile/event/touch"
+)
+
+// SnackbarDur is duration of snackbars sliding in and out.
+var SnackbarDur = 250 * time.Millisecond
+
+// Timeouts of snackbars; snackbars without timeout are shown until dismissed.
+const (
+	SnackbarShort = 4 * time.Second
+	SnackbarLong  = 10 * time.Second
+)
+
+// Snackbar is a brief message shown along the bottom of the window, with an
+// optional action. Snackbars of an environment are queued and shown one at a
+// time; a snackbar is dismissed by its action, by swiping it away, or when
+// its timeout passes. Snackbars without action serve as toasts.
+//
+// Floating action buttons beneath a visible snackbar are moved up above it.
+// https://material.io/guidelines/components/snackbars-toasts.html
+type Snackbar struct {
+	*Material
+
+	// Timeout is duration snackbar is shown for once slid in; if zero,
+	// snackbar is shown until dismissed.
+	Timeout time.Duration
+
+	// OnAction is called when action of snackbar is pressed, as snackbar is
+	// dismissed.
+	OnAction func()
+
+	// OnDismiss is called after snackbar is dismissed by any means.
+	OnDismiss func()
+
+	action *Button
+
+	shown   float32 // fraction slid in
+	dx      float32 // offset swiped
+	from    float32 // x of touch swiping, less dx
+	closing bool    // sliding out
+
+	anim, timer *Handle
+}
+
+// SetAction sets btn as a text button pressed to act on the message of
+// snackbar, calling OnAction.
+func (sb *Snackbar) SetAction(btn *Button) {
+	btn.BehaviorFlags = DescriptorFlat
+	btn.textButton, btn.inverse = true, true
+	btn.text.middle = true
+	btn.owner = sb.Material
+	btn.restyle()
+	btn.OnPress = func() {
+		if sb.Hidden() || sb.closing {
+			return
+		}
+		sb.Hide()
+		if sb.OnAction != nil {
+			sb.OnAction()
+		}
+	}
+	sb.action = btn
+}
+
+// Show queues snackbar to be slid in after snackbars queued before it are
+// dismissed.
+func (sb *Snackbar) Show() {
+	env := sb.env
+	for _, x := range env.snackbars {
+		if x == sb {
+			return
+		}
+	}
+	env.snackbars = append(env.snackbars, sb)
+	if env.snackbars[0] == sb {
+		sb.present()
+	}
+}
+
+// Hide slides snackbar out if shown, or removes it from the queue.
+func (sb *Snackbar) Hide() {
+	env := sb.env
+	switch {
+	case len(env.snackbars) == 0 || sb.closing:
+	case env.snackbars[0] == sb:
+		sb.closing = true
+		sb.slide(0, sb.dx, Accelerate).onEnd(sb.dismissed)
+	default:
+		for i, x := range env.snackbars {
+			if x == sb {
+				env.snackbars = append(env.snackbars[:i], env.snackbars[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// present slides in snackbar at front of queue.
+func (sb *Snackbar) present() {
+	sb.shown, sb.dx = 0, 0
+	sb.place()
+	sb.hidden, sb.closing = false, false
+	sb.wait(sb.slide(1, 0, Decelerate))
+}
+
+// dismissed hides snackbar and presents next of queue once slid out.
+func (sb *Snackbar) dismissed(r Reason) {
+	if r != Finished || !sb.closing {
+		return
+	}
+	env := sb.env
+	sb.hidden, sb.closing = true, false
+	env.snackbars = env.snackbars[1:]
+	if sb.OnDismiss != nil {
+		sb.OnDismiss()
+	}
+	if len(env.snackbars) != 0 {
+		env.snackbars[0].present()
+	}
+}
+
+// wait starts timeout of snackbar after h finishes.
+func (sb *Snackbar) wait(h *Handle) {
+	if sb.Timeout <= 0 {
+		return
+	}
+	sb.timer = h.Then(Animation{Dur: sb.Timeout})
+	sb.timer.onEnd(func(r Reason) {
+		if r == Finished {
+			sb.Hide()
+		}
+	})
+}
+
+// slide animates snackbar to fraction shown, swiped by dx, cancelling its
+// timeout.
+func (sb *Snackbar) slide(shown, dx float32, sig Curve) *Handle {
+	if sb.timer != nil {
+		sb.timer.Cancel()
+	}
+	if sb.anim != nil {
+		sb.anim.Cancel()
+	}
+	fromShown, fromDx := sb.shown, sb.dx
+	sb.anim = sb.env.Animate(Animation{
+		Sig: sig,
+		Dur: SnackbarDur,
+		Start: func() {
+			fromShown, fromDx = sb.shown, sb.dx
+		},
+		Interp: func(dt float32) {
+			sb.shown = fromShown + (shown-fromShown)*dt
+			sb.dx = fromDx + (dx-fromDx)*dt
+			sb.place()
+		},
+	})
+	return sb.anim
+}
+
+// place offsets snackbar from its layout below the window by the fraction not
+// shown and along by dx, and offsets floating action buttons beneath its
+// layout up by the fraction shown. Offsets apply where drawn and touched, so
+// layout and tweens of world are unaffected.
+func (sb *Snackbar) place() {
+	h := sb.world[1][1]
+	sb.offset = [2]float32{sb.dx, -(1 - sb.shown) * h}
+
+	x, y, w := sb.world[0][3], sb.world[1][3], sb.world[0][0]
+	for _, sheet := range sb.env.sheets {
+		fab, ok := sheet.(*FloatingActionButton)
+		if !ok {
+			continue
+		}
+		fx, fy, fw := fab.world[0][3], fab.world[1][3], fab.world[0][0]
+		fab.offset[1] = 0
+		if fx < x+w && x < fx+fw && fy < y+h {
+			fab.offset[1] = sb.shown * h
+		}
+	}
+}
+
+// placeSnackbar places snackbar shown, if any, for its current layout.
+func (env *Environment) placeSnackbar() {
+	if len(env.snackbars) != 0 {
+		env.snackbars[0].place()
+	}
+}
+
+// drag follows a touch swiping snackbar, dismissing it if released past a
+// third of its width, otherwise returning it.
+func (sb *Snackbar) drag(typ touch.Type, x float32, now time.Time) {
+	if sb.closing {
+		return
+	}
+	switch typ {
+	case touch.TypeBegin:
+		if sb.timer != nil {
+			sb.timer.Cancel()
+		}
+		if sb.anim != nil {
+			sb.anim.Cancel()
+		}
+		sb.from = x - sb.dx
+	case touch.TypeMove:
+		sb.dx = x - sb.from
+		sb.place()
+	case touch.TypeEnd:
+		w := sb.world[0][0]
+		if math.Abs(float64(sb.dx)) < float64(w/3) {
+			sb.wait(sb.slide(1, 0, Decelerate))
+			return
+		}
+		sb.closing = true
+		sb.slide(0, float32(math.Copysign(float64(w), float64(sb.dx))), Accelerate).onEnd(sb.dismissed)
+	}
+}
+
+// Constraints anchors snackbar to bottom of environment, spanning its width
+// on small windows, otherwise centered and sized to fit its message between
+// 288dp and 568dp. Snackbars are 48dp high, or 80dp with two lines.
+func (sb *Snackbar) Constraints(env *Environment) []simplex.Constraint {
+	var (
+		pad = Dp(24).Px()
+		h   = Dp(48).Px()
+		w   = float32(windowSize.WidthPx)
+		aw  float32 // width of action
+	)
+	if strings.Contains(sb.text.value, "\n") {
+		h = Dp(80).Px()
+	}
+	sb.text.inset = pad
+
+	var cns []simplex.Constraint
+	if btn := sb.action; btn != nil {
+		aw = btn.fitText()
+		cns = append(cns,
+			btn.Width(aw), btn.Height(Dp(36).Px()),
+			btn.EndIn(sb.Box, Dp(8).Px()), btn.CenterVerticalIn(sb.Box),
+			btn.ElevateAbove(sb.Box, partZ),
+		)
+		aw += Dp(8).Px()
+	}
+
+	sb.Roundness = 0
+	if w >= Dp(600).Px() {
+		w = textWidth(sb.text.value, sb.text.height) + 2*pad + aw
+		if min := Dp(288).Px(); w < min {
+			w = min
+		}
+		if max := Dp(568).Px(); w > max {
+			w = max
+		}
+		sb.Roundness = sb.theme().Shape.Small.Px()
+	}
+	return append(cns,
+		sb.Width(w), sb.Height(h),
+		sb.CenterHorizontalIn(env.Box), sb.BottomIn(env.Box, 0),
+		sb.Elevate(ElevationSnackbar),
+	)
+}
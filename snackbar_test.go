@@ -0,0 +1,116 @@
+package material
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/mobile/event/size"
+	"golang.org/x/mobile/event/touch"
+)
+
+// newTestSnackbar returns snackbar laid out along bottom of a 400 by 400
+// window.
+func newTestSnackbar(env *Environment) *Snackbar {
+	sb := env.NewSnackbar(nil)
+	sb.world[0][0], sb.world[1][1] = 400, 48
+	return sb
+}
+
+func step(env *Environment, clock *testClock, d time.Duration) {
+	env.Scheduler().Step()
+	clock.Add(d)
+	env.Scheduler().Step()
+}
+
+func TestSnackbarQueue(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1, WidthPx: 400, HeightPx: 400}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	a, b := newTestSnackbar(env), newTestSnackbar(env)
+	var dismissed []*Snackbar
+	a.OnDismiss = func() { dismissed = append(dismissed, a) }
+	b.OnDismiss = func() { dismissed = append(dismissed, b) }
+
+	a.Show()
+	b.Show()
+	if a.Hidden() || !b.Hidden() {
+		t.Fatalf("have hidden %v, %v, want second queued", a.Hidden(), b.Hidden())
+	}
+
+	step(env, clock, SnackbarDur)
+	if _, y := a.at(); y != 0 {
+		t.Errorf("have y %v after sliding in, want 0", y)
+	}
+	step(env, clock, a.Timeout)
+	step(env, clock, SnackbarDur)
+	if !a.Hidden() || b.Hidden() || len(dismissed) != 1 {
+		t.Fatalf("have hidden %v, %v after timeout, want second shown", a.Hidden(), b.Hidden())
+	}
+	if y := a.world[1][3]; y != 0 {
+		t.Errorf("have world y %v after dismissed, want layout unchanged", y)
+	}
+
+	b.Hide()
+	step(env, clock, SnackbarDur)
+	if !b.Hidden() || len(dismissed) != 2 || len(env.snackbars) != 0 {
+		t.Errorf("have %v dismissed, %v queued, want 2 dismissed", len(dismissed), len(env.snackbars))
+	}
+}
+
+func TestSnackbarLiftsFAB(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1, WidthPx: 400, HeightPx: 400}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	fab := env.NewFloatingActionButton(nil)
+	fab.world[0][3], fab.world[1][3] = 328, 16
+	fab.world[0][0], fab.world[1][1] = 56, 56
+	sb := newTestSnackbar(env)
+
+	sb.Show()
+	step(env, clock, SnackbarDur)
+	if _, y := fab.at(); y != 64 {
+		t.Errorf("have fab y %v with snackbar shown, want 64", y)
+	}
+	fab.world[1][3] = 20 // as moved by a tween or layout
+	env.placeSnackbar()
+	if _, y := fab.at(); y != 68 {
+		t.Errorf("have fab y %v after moving it, want 68", y)
+	}
+	fab.world[1][3] = 16
+
+	sb.Hide()
+	step(env, clock, SnackbarDur)
+	if _, y := fab.at(); y != 16 {
+		t.Errorf("have fab y %v after snackbar hidden, want 16", y)
+	}
+}
+
+func TestSnackbarSwipe(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1, WidthPx: 400, HeightPx: 400}
+	clock := new(testClock)
+	env := &Environment{Clock: clock}
+	sb := newTestSnackbar(env)
+	sb.Timeout = 0
+	sb.Show()
+	step(env, clock, SnackbarDur)
+
+	swipe := func(dx float32) {
+		for _, ev := range []touch.Event{
+			{X: 100, Y: 376, Type: touch.TypeBegin},
+			{X: 100 + dx, Y: 376, Type: touch.TypeMove},
+			{X: 100 + dx, Y: 376, Type: touch.TypeEnd},
+		} {
+			env.Touch(ev)
+		}
+		step(env, clock, SnackbarDur)
+	}
+
+	swipe(50)
+	if x, _ := sb.at(); sb.Hidden() || x != 0 {
+		t.Fatalf("have hidden %v at x %v after short swipe, want returned", sb.Hidden(), x)
+	}
+	swipe(-200)
+	if !sb.Hidden() {
+		t.Errorf("snackbar not dismissed by swipe")
+	}
+}
@@ -0,0 +1,160 @@
+package material
+
+import (
+	"math"
+	"time"
+)
+
+// Spring is a damped harmonic oscillator moving from 0 to rest at 1. Spring is
+// used in place of a fixed duration curve with Apply.
+type Spring struct {
+	Stiffness float64 // force per unit of displacement
+	Damping   float64 // force per unit of velocity
+	Mass      float64 // if zero, a mass of 1 is used
+
+	// Velocity at start in units per second, where a unit is the distance
+	// from start to rest. See VelocityOf for converting from pixels.
+	Velocity float64
+}
+
+// DefaultSpring settles quickly with little overshoot.
+var DefaultSpring = Spring{Stiffness: 400, Damping: 30, Mass: 1}
+
+// springRest is the displacement and velocity below which a spring is at rest.
+const springRest = 1e-3
+
+// VelocityOf returns spring with initial velocity v, in pixels per second, for
+// an animation moving distance pixels.
+func (s Spring) VelocityOf(v, distance float32) Spring {
+	if distance != 0 {
+		s.Velocity = float64(v / distance)
+	}
+	return s
+}
+
+// displacement returns distance from rest and velocity at t seconds.
+func (s Spring) displacement(t float64) (x, v float64) {
+	m := s.Mass
+	if m == 0 {
+		m = 1
+	}
+	w0 := math.Sqrt(s.Stiffness / m)
+	zeta := s.Damping / (2 * math.Sqrt(s.Stiffness*m))
+	x0, v0 := -1.0, s.Velocity
+
+	switch {
+	case zeta < 1: // underdamped
+		wd := w0 * math.Sqrt(1-zeta*zeta)
+		a, b := x0, (v0+zeta*w0*x0)/wd
+		e := math.Exp(-zeta * w0 * t)
+		cos, sin := math.Cos(wd*t), math.Sin(wd*t)
+		x = e * (a*cos + b*sin)
+		v = -zeta*w0*x + e*(-a*wd*sin+b*wd*cos)
+	case zeta == 1: // critically damped
+		a, b := x0, v0+w0*x0
+		e := math.Exp(-w0 * t)
+		x = e * (a + b*t)
+		v = e * (b - w0*(a+b*t))
+	default: // overdamped
+		d := w0 * math.Sqrt(zeta*zeta-1)
+		r1, r2 := -zeta*w0+d, -zeta*w0-d
+		c2 := (v0 - r1*x0) / (r2 - r1)
+		c1 := x0 - c2
+		e1, e2 := math.Exp(r1*t), math.Exp(r2*t)
+		x = c1*e1 + c2*e2
+		v = c1*r1*e1 + c2*r2*e2
+	}
+	return x, v
+}
+
+// Duration returns time until spring comes to rest, at most ten seconds.
+func (s Spring) Duration() time.Duration {
+	if s.Stiffness <= 0 {
+		return 0
+	}
+	const step, max = 1.0 / 240, 10.0
+	rest := 0.0
+	for t := 0.0; t < max; t += step {
+		if x, v := s.displacement(t); math.Abs(x) > springRest || math.Abs(v) > springRest {
+			rest = t + step
+		}
+	}
+	return time.Duration(rest * float64(time.Second))
+}
+
+type springCurve struct {
+	Spring
+	dur float64 // seconds
+}
+
+func (s springCurve) At(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	x, _ := s.displacement(t * s.dur)
+	return 1 + x
+}
+
+// Curve returns position of spring at unit progress of Duration.
+func (s Spring) Curve() Curve {
+	return springCurve{s, s.Duration().Seconds()}
+}
+
+// Apply returns anim interpolated by spring, lasting until spring comes to rest.
+func (s Spring) Apply(anim Animation) Animation {
+	anim.Sig = s.Curve()
+	anim.Dur = s.Duration()
+	return anim
+}
+
+// Spring sets tween to be interpolated by spring, lasting until spring comes to rest.
+func (tw *Tween) Spring(s Spring) *Tween {
+	tw.Sig, tw.Dur = s.Curve(), s.Duration()
+	return tw
+}
+
+// VelocityTracker estimates velocity of a pointer from recent samples, such as
+// those of touch.TypeMove events, to hand off a drag to a Spring.
+type VelocityTracker struct {
+	samples [8]struct {
+		x, y float32
+		t    time.Time
+	}
+	n int
+}
+
+// velocityWindow is the age of the oldest sample considered by VelocityTracker.
+const velocityWindow = 100 * time.Millisecond
+
+// Add records position x, y at time t.
+func (vt *VelocityTracker) Add(x, y float32, t time.Time) {
+	s := &vt.samples[vt.n%len(vt.samples)]
+	s.x, s.y, s.t = x, y, t
+	vt.n++
+}
+
+// Reset discards all samples.
+func (vt *VelocityTracker) Reset() { vt.n = 0 }
+
+// Velocity returns velocity in pixels per second between the oldest and newest
+// samples within 100ms of the newest sample.
+func (vt *VelocityTracker) Velocity() (vx, vy float32) {
+	if vt.n < 2 {
+		return 0, 0
+	}
+	size := len(vt.samples)
+	last := vt.samples[(vt.n-1)%size]
+	first := last
+	for i := 2; i <= vt.n && i <= size; i++ {
+		s := vt.samples[(vt.n-i)%size]
+		if last.t.Sub(s.t) > velocityWindow {
+			break
+		}
+		first = s
+	}
+	dt := float32(last.t.Sub(first.t).Seconds())
+	if dt == 0 {
+		return 0, 0
+	}
+	return (last.x - first.x) / dt, (last.y - first.y) / dt
+}
@@ -0,0 +1,47 @@
+package material
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSpring(t *testing.T) {
+	for _, s := range []Spring{
+		DefaultSpring,
+		{Stiffness: 100, Damping: 20},               // critically damped
+		{Stiffness: 100, Damping: 40},               // overdamped
+		{Stiffness: 400, Damping: 10, Velocity: 20}, // bouncy with carried velocity
+	} {
+		d := s.Duration()
+		if d <= 0 || d >= 10*time.Second {
+			t.Errorf("%+v: unexpected duration %v", s, d)
+			continue
+		}
+		c := s.Curve()
+		if x := c.At(0); math.Abs(x) > 1e-9 {
+			t.Errorf("%+v: have %v at start, want 0", s, x)
+		}
+		if x := c.At(0.999); math.Abs(1-x) > springRest*2 {
+			t.Errorf("%+v: have %v near end, want 1", s, x)
+		}
+		if x := c.At(1); x != 1 {
+			t.Errorf("%+v: have %v at end, want 1", s, x)
+		}
+	}
+}
+
+func TestVelocityTracker(t *testing.T) {
+	var vt VelocityTracker
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		vt.Add(float32(i)*10, 0, start.Add(time.Duration(i)*10*time.Millisecond))
+	}
+	if vx, vy := vt.Velocity(); math.Abs(float64(vx-1000)) > 1e-3 || vy != 0 {
+		t.Errorf("have %v, %v; want 1000, 0", vx, vy)
+	}
+	vt.Reset()
+	if vx, _ := vt.Velocity(); vx != 0 {
+		t.Errorf("have %v after reset, want 0", vx)
+	}
+}
@@ -0,0 +1,64 @@
+package material
+
+import "math"
+
+// Swatch is a tonal set of a color, from lightest tone T50 to darkest T900,
+// and accents A100 to A700, as in the material color palette.
+type Swatch struct {
+	T50, T100, T200, T300, T400, T500, T600, T700, T800, T900 Color
+	A100, A200, A400, A700                                    Color
+}
+
+// NewSwatch returns swatch generated from seed, which becomes tone T500.
+// Lighter tones are mixed with white, darker tones with seed multiplied by
+// itself, and accents from a tetradic hue of seed that is then saturated and
+// lightened.
+func NewSwatch(seed Color) Swatch {
+	seed = seed.WithAlpha(0xFF)
+	light, dark := White, multiply(seed, seed)
+
+	h, s, l := seed.HSL()
+	tetrad := HSL(h+270, s, l)
+	base := mixRGB(dark, tetrad, 0.15)
+	accent := func(saturate, lighten float64) Color {
+		h, s, l := base.HSL()
+		return HSL(h, math.Min(1, s+saturate), math.Min(1, l+lighten))
+	}
+
+	return Swatch{
+		T50:  mixRGB(light, seed, 0.12),
+		T100: mixRGB(light, seed, 0.30),
+		T200: mixRGB(light, seed, 0.50),
+		T300: mixRGB(light, seed, 0.70),
+		T400: mixRGB(light, seed, 0.85),
+		T500: seed,
+		T600: mixRGB(dark, seed, 0.87),
+		T700: mixRGB(dark, seed, 0.70),
+		T800: mixRGB(dark, seed, 0.54),
+		T900: mixRGB(dark, seed, 0.25),
+		A100: accent(0.80, 0.65),
+		A200: accent(0.80, 0.55),
+		A400: accent(1, 0.45),
+		A700: accent(1, 0.40),
+	}
+}
+
+// Palette returns palette of swatch with T500 as primary color, T700 and T100
+// as dark and light tones, and A200 as accent.
+func (sw Swatch) Palette() Palette {
+	return Palette{Primary: sw.T500, Dark: sw.T700, Light: sw.T100, Accent: sw.A200}
+}
+
+// mixRGB returns blend of a and b by t in sRGB space.
+func mixRGB(a, b Color, t float64) Color {
+	ar, ag, ab, aa := a.RGBA64()
+	br, bg, bb, ba := b.RGBA64()
+	return unitColor(ar+(br-ar)*t, ag+(bg-ag)*t, ab+(bb-ab)*t, aa+(ba-aa)*t)
+}
+
+// multiply returns product of each component of a and b.
+func multiply(a, b Color) Color {
+	ar, ag, ab, aa := a.RGBA64()
+	br, bg, bb, ba := b.RGBA64()
+	return unitColor(ar*br, ag*bg, ab*bb, aa*ba)
+}
@@ -0,0 +1,34 @@
+package material
+
+import "testing"
+
+func TestNewSwatch(t *testing.T) {
+	for _, seed := range []Color{Red500, Indigo500, Teal500, 0x7B1FA280} {
+		sw := NewSwatch(seed)
+		if sw.T500 != seed.WithAlpha(0xFF) {
+			t.Errorf("%v: have T500 %v, want seed", seed, sw.T500)
+		}
+		tones := []Color{sw.T50, sw.T100, sw.T200, sw.T300, sw.T400, sw.T500, sw.T600, sw.T700, sw.T800, sw.T900}
+		for i := 1; i < len(tones); i++ {
+			if tones[i].Luminance() >= tones[i-1].Luminance() {
+				t.Errorf("%v: tone %v is not darker than tone %v", seed, tones[i], tones[i-1])
+			}
+		}
+		for _, c := range append(tones, sw.A100, sw.A200, sw.A400, sw.A700) {
+			if c.Alpha() != 0xFF {
+				t.Errorf("%v: tone %v is not opaque", seed, c)
+			}
+		}
+		if p := sw.Palette(); p.Primary != sw.T500 || p.Accent != sw.A200 {
+			t.Errorf("%v: unexpected palette %+v", seed, p)
+		}
+	}
+
+	// generated tones approximate hand-picked swatches.
+	sw := NewSwatch(Red500)
+	for _, tt := range []struct{ have, want Color }{{sw.T100, Red100}, {sw.T700, Red700}} {
+		if c := Contrast(tt.have, tt.want); c > 1.3 {
+			t.Errorf("have %v, want near %v; contrast %v", tt.have, tt.want, c)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package material
+
+import "math"
+
+// Shape is a family of corner radii for components by size.
+type Shape struct {
+	Small  Dp // buttons, chips and text fields
+	Medium Dp // cards, menus and dialogs
+	Large  Dp // sheets and navigation drawers
+}
+
+// Typography is the type scale of a theme, as text heights.
+type Typography struct {
+	H1, H2, H3, H4, H5, H6 Dp
+	Subtitle1, Subtitle2   Dp
+	Body1, Body2           Dp
+	Button, Caption        Dp
+	Overline               Dp
+}
+
+// Theme is the colors, shape and typography components are styled with.
+//
+// Primary and Accent are the brand colors of a theme, Dark and Light are
+// tones of Primary. Surface colors components such as menus and cards, and
+// Background is the color behind all surfaces. Colors prefixed with On are
+// for text and icons drawn over the color they name.
+type Theme struct {
+	Palette
+
+	Surface, Background, Error Color
+
+	OnPrimary, OnAccent, OnSurface, OnBackground, OnError Color
+
+	Shape Shape
+	Type  Typography
+
+	// IsDark themes lighten raised surfaces by elevation; see ElevationOverlay.
+	IsDark bool
+}
+
+// DefaultTheme is the theme of environments before SetTheme or SetPalette is called.
+var DefaultTheme = Theme{
+	Palette: Palette{
+		Primary: Indigo500,
+		Dark:    Indigo700,
+		Light:   Indigo100,
+		Accent:  PinkA200,
+	},
+	Surface:      White,
+	Background:   Grey50,
+	Error:        0xB00020FF,
+	OnPrimary:    White,
+	OnAccent:     White,
+	OnSurface:    Black,
+	OnBackground: Black,
+	OnError:      White,
+	Shape:        Shape{Small: 4, Medium: 4, Large: 0},
+	Type: Typography{
+		H1: 96, H2: 60, H3: 48, H4: 34, H5: 24, H6: 20,
+		Subtitle1: 16, Subtitle2: 14,
+		Body1: 16, Body2: 14,
+		Button: 14, Caption: 12,
+		Overline: 10,
+	},
+}
+
+// DarkTheme is the dark variant of DefaultTheme.
+var DarkTheme = DefaultTheme.DarkVariant()
+
+// DarkVariant returns dark variant of theme. Surfaces become dark grey, the
+// light tone of primary color becomes primary, and content colors are picked
+// to contrast the colors they are drawn over.
+// https://material.io/design/color/dark-theme.html
+func (th Theme) DarkVariant() Theme {
+	th.IsDark = true
+	th.Primary, th.Dark = th.Light, th.Primary
+	th.Surface, th.Background = 0x121212FF, 0x121212FF
+	th.Error = 0xCF6679FF
+	th.OnPrimary = OnColor(th.Primary)
+	th.OnAccent = OnColor(th.Accent)
+	th.OnSurface = OnColor(th.Surface)
+	th.OnBackground = OnColor(th.Background)
+	th.OnError = OnColor(th.Error)
+	return th
+}
+
+// ElevationOverlay returns opacity of white overlaid on surfaces at elevation
+// e in dark themes, so higher surfaces appear lighter.
+func ElevationOverlay(e Elevation) float32 {
+	if e <= 0 {
+		return 0
+	}
+	return float32((4.5*math.Log(float64(e)+1) + 2) / 100)
+}
+
+// fill returns color material is drawn with. In dark themes, raised surfaces
+// colored with the theme's surface color are lightened by elevation.
+func (mtrl *Material) fill(th Theme) (r, g, b float32) {
+	r, g, b = mtrl.cr, mtrl.cg, mtrl.cb
+	if !th.IsDark || mtrl.BehaviorFlags&DescriptorRaised == 0 || mtrl.overlay {
+		return r, g, b
+	}
+	if sr, sg, sb, _ := th.Surface.RGBA(); r != sr || g != sg || b != sb {
+		return r, g, b
+	}
+	a := ElevationOverlay(mtrl.Elevation())
+	return r + (1-r)*a, g + (1-g)*a, b + (1-b)*a
+}
+
+// background returns color content of material is drawn over; the surface
+// color of th if material is flat.
+func (mtrl *Material) background(th Theme) Color {
+	if mtrl.BehaviorFlags&DescriptorRaised == 0 {
+		return th.Surface
+	}
+	r, g, b := mtrl.fill(th)
+	return colorOf(r, g, b, 1)
+}
+
+// textColor returns color of text; if not set with SetTextColor, black or
+// white is picked to contrast background.
+func (mtrl *Material) textColor(th Theme) (r, g, b, a float32) {
+	if mtrl.text.set {
+		return mtrl.text.r, mtrl.text.g, mtrl.text.b, mtrl.text.a
+	}
+	r, g, b, _ = OnColor(mtrl.background(th)).RGBA()
+	return r, g, b, mtrl.text.a
+}
+
+// iconColor returns color of icon; if not set with SetIconColor, black or
+// white is picked to contrast background.
+func (mtrl *Material) iconColor(th Theme) (r, g, b, a float32) {
+	if mtrl.icon.set {
+		return mtrl.icon.r, mtrl.icon.g, mtrl.icon.b, mtrl.icon.a
+	}
+	r, g, b, _ = OnColor(mtrl.background(th)).RGBA()
+	return r, g, b, mtrl.icon.a
+}
+
+// Themed is implemented by sheets styled by theme. ApplyTheme is called when
+// a sheet is added to an environment, when the environment's theme changes,
+// and when screen density changes so dp values can be converted again.
+type Themed interface {
+	ApplyTheme(th Theme)
+}
+
+// Theme returns current theme of environment.
+func (env *Environment) Theme() Theme {
+	if env.theme == nil {
+		return DefaultTheme
+	}
+	return *env.theme
+}
+
+// SetTheme sets theme of environment and restyles sheets implementing Themed,
+// overwriting any colors set on them since.
+func (env *Environment) SetTheme(th Theme) {
+	env.theme = &th
+	env.applyTheme()
+}
+
+func (env *Environment) applyTheme() {
+	th := env.Theme()
+	for _, sheet := range env.sheets {
+		if t, ok := sheet.(Themed); ok {
+			t.ApplyTheme(th)
+		}
+	}
+}
+
+// ApplyTheme colors button with primary color if raised; text and icons are
+// colored for primary if raised, or for surface if flat. Text buttons, such as
+// dialog actions, are labeled with primary color, or accent color on inverse
+// surfaces such as snackbars.
+func (btn *Button) ApplyTheme(th Theme) {
+	switch {
+	case btn.textButton && btn.inverse:
+		btn.SetTextColor(th.Accent)
+		btn.SetTextHeight(th.Type.Button.Px())
+	case btn.textButton:
+		btn.SetTextColor(th.Primary)
+		btn.SetTextHeight(th.Type.Button.Px())
+	case btn.BehaviorFlags&DescriptorFlat != 0:
+		btn.SetIconColor(th.OnSurface)
+		btn.SetTextColor(th.OnSurface)
+	default:
+		btn.SetColor(th.Primary)
+		btn.SetIconColor(th.OnPrimary)
+		btn.SetTextColor(th.OnPrimary)
+	}
+	btn.Roundness = th.Shape.Small.Px()
+}
+
+// restyle applies theme of button's environment, such as after changing
+// behavior flags.
+func (btn *Button) restyle() {
+	th := DefaultTheme
+	if btn.env != nil {
+		th = btn.env.Theme()
+	}
+	btn.ApplyTheme(th)
+}
+
+// ApplyTheme colors floating action button with accent color.
+func (fab *FloatingActionButton) ApplyTheme(th Theme) {
+	fab.SetColor(th.Accent)
+	fab.SetIconColor(th.OnAccent)
+}
+
+// ApplyTheme colors toolbar with light tone of primary color, or surface color
+// if theme is dark, and styles its title with H6 text.
+func (bar *Toolbar) ApplyTheme(th Theme) {
+	if th.IsDark {
+		bar.SetColor(th.Surface)
+	} else {
+		bar.SetColor(th.Light)
+	}
+	bar.Title.SetTextColor(th.OnSurface)
+	bar.Title.SetTextHeight(th.Type.H6.Px())
+}
+
+// selectionColors returns colors of selection controls when checked and when
+// unchecked.
+func selectionColors(th Theme, disabled bool) (on, off Color) {
+	if disabled {
+		c := th.OnSurface.WithAlpha(97) // 38%
+		return c, c
+	}
+	return th.Accent, th.OnSurface
+}
+
+// ApplyTheme colors checkbox with accent color if checked, or its outline with
+// surface content color if unchecked.
+func (cb *Checkbox) ApplyTheme(th Theme) {
+	on, off := selectionColors(th, cb.Disabled())
+	if cb.Checked() {
+		off = on
+	}
+	cb.box.SetIconColor(off)
+	cb.mark.SetColor(on)
+	cb.mark.SetIconColor(th.OnAccent)
+	cb.mark.Roundness = Dp(2).Px()
+	cb.Ink.Color = off
+}
+
+// ApplyTheme colors radio button with accent color if checked, or its outline
+// with surface content color if unchecked.
+func (rb *RadioButton) ApplyTheme(th Theme) {
+	on, off := selectionColors(th, rb.Disabled())
+	if rb.Checked() {
+		off = on
+	}
+	rb.ring.SetIconColor(off)
+	rb.dot.SetColor(on)
+	rb.Ink.Color = off
+}
+
+// ApplyTheme colors thumb and track of switch with accent color if on, or
+// greys if off.
+func (sw *Switch) ApplyTheme(th Theme) {
+	thumb, track := Grey50, th.OnSurface.WithAlpha(97) // 38%
+	if th.IsDark {
+		thumb = Grey400
+	}
+	switch {
+	case sw.Disabled():
+		thumb, track = Grey400, th.OnSurface.WithAlpha(31) // 12%
+		if th.IsDark {
+			thumb = Grey800
+		}
+	case sw.Checked():
+		thumb, track = th.Accent, th.Accent.WithAlpha(138) // 54%
+	}
+	sw.thumb.SetColor(thumb)
+	sw.track.SetColor(track)
+	sw.track.Roundness = Dp(7).Px()
+	sw.thumb.Ink.Color = th.OnSurface
+	if sw.Checked() {
+		sw.thumb.Ink.Color = th.Accent
+	}
+}
+
+// ApplyTheme colors slider with accent color, and its track with accent color
+// at lower opacity.
+func (sl *Slider) ApplyTheme(th Theme) {
+	c := th.Accent
+	if sl.Disabled() {
+		c = th.OnSurface.WithAlpha(97) // 38%
+	}
+	sl.track.SetColor(c.WithAlpha(61)) // 24%
+	sl.fill.SetColor(c)
+	for _, thumb := range sl.thumbs {
+		if thumb != nil {
+			thumb.SetColor(c)
+			thumb.Ink.Color = c
+		}
+	}
+	sl.label.SetColor(c)
+	sl.label.SetTextColor(OnColor(c))
+	sl.label.SetTextHeight(th.Type.Caption.Px())
+	sl.colorTicks(th)
+}
+
+// colorTicks colors tick marks of slider to contrast fill if within selected
+// range, else track.
+func (sl *Slider) colorTicks(th Theme) {
+	c := th.Accent
+	if sl.Disabled() {
+		c = th.OnSurface
+	}
+	for i, tick := range sl.ticks {
+		v := math.Min(sl.max, sl.min+float64(i)*sl.step)
+		if sl.vals[0] <= v && v <= sl.vals[1] {
+			tick.SetColor(OnColor(c).WithAlpha(138)) // 54%
+		} else {
+			tick.SetColor(c.WithAlpha(138))
+		}
+	}
+}
+
+// ApplyTheme colors dialog with surface color, styling its title with H6 text
+// and its body with Body1 text. Full-screen dialogs have square corners.
+func (dlg *Dialog) ApplyTheme(th Theme) {
+	dlg.SetColor(th.Surface)
+	dlg.Roundness = th.Shape.Medium.Px()
+	if dlg.Kind == DialogFullScreen {
+		dlg.Roundness = 0
+	}
+	dlg.Title.SetTextColor(th.OnSurface)
+	dlg.Title.SetTextHeight(th.Type.H6.Px())
+	dlg.Body.SetTextColor(th.OnSurface.WithAlpha(153)) // 60%
+	dlg.Body.SetTextHeight(th.Type.Body1.Px())
+	for _, btn := range dlg.items {
+		btn.SetTextHeight(th.Type.Subtitle1.Px())
+	}
+	for _, l := range dlg.labels {
+		l.SetTextColor(th.OnSurface)
+		l.SetTextHeight(th.Type.Subtitle1.Px())
+	}
+}
+
+// ApplyTheme colors snackbar dark grey, or light grey if theme is dark,
+// styling its message with Body2 text.
+func (sb *Snackbar) ApplyTheme(th Theme) {
+	c := Grey800
+	if th.IsDark {
+		c = Grey200
+	}
+	sb.SetColor(c)
+	sb.SetTextColor(OnColor(c))
+	sb.SetTextHeight(th.Type.Body2.Px())
+}
+
+// ApplyTheme colors menu with surface color.
+func (mu *Menu) ApplyTheme(th Theme) {
+	mu.SetColor(th.Surface)
+	mu.Roundness = th.Shape.Medium.Px()
+}
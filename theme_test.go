@@ -0,0 +1,102 @@
+package material
+
+import (
+	"testing"
+
+	"golang.org/x/mobile/event/size"
+)
+
+type themedSheet struct {
+	*Material
+	applied int
+}
+
+func (ts *themedSheet) ApplyTheme(th Theme) {
+	ts.applied++
+	ts.SetColor(th.Error)
+}
+
+func TestEnvironmentSetTheme(t *testing.T) {
+	windowSize = size.Event{PixelsPerPt: 1}
+	env := new(Environment)
+	btn := env.NewButton(nil)
+	ts := &themedSheet{Material: New(nil, Black)}
+	env.add(ts)
+
+	if r, g, b, _ := DefaultTheme.Primary.RGBA(); btn.cr != r || btn.cg != g || btn.cb != b {
+		t.Errorf("button not colored by default theme")
+	}
+	if ts.applied != 1 {
+		t.Errorf("have %v calls to ApplyTheme, want 1", ts.applied)
+	}
+
+	env.SetPalette(Palette{Primary: Red500})
+	if r, g, b, _ := Red500.RGBA(); btn.cr != r || btn.cg != g || btn.cb != b {
+		t.Errorf("button not recolored by palette")
+	}
+	if env.Theme().Surface != DefaultTheme.Surface {
+		t.Errorf("SetPalette changed surface of theme")
+	}
+
+	env.setSize(size.Event{PixelsPerPt: 1})
+	if ts.applied != 2 {
+		t.Errorf("have %v calls to ApplyTheme, want 2", ts.applied)
+	}
+	env.setSize(size.Event{PixelsPerPt: 2})
+	if ts.applied != 3 || btn.Roundness != DefaultTheme.Shape.Small.Px() {
+		t.Errorf("sheets not restyled when density changed")
+	}
+}
+
+func TestContrast(t *testing.T) {
+	if c := Contrast(Black, White); c < 20.99 || c > 21.01 {
+		t.Errorf("have contrast %v of black and white, want 21", c)
+	}
+	if c := Contrast(Red500, Red500); c != 1 {
+		t.Errorf("have contrast %v of same color, want 1", c)
+	}
+	for _, tt := range []struct{ bg, want Color }{
+		{White, Black}, {Black, White}, {Yellow500, Black}, {Indigo500, White}, {0x121212FF, White},
+	} {
+		if c := OnColor(tt.bg); c != tt.want {
+			t.Errorf("OnColor(%#x): have %#x, want %#x", tt.bg, c, tt.want)
+		}
+	}
+}
+
+func TestDarkTheme(t *testing.T) {
+	th := DarkTheme
+	if !th.IsDark || th.OnSurface != White || th.Primary != DefaultTheme.Light {
+		t.Errorf("unexpected dark variant %+v", th)
+	}
+
+	m := New(nil, th.Surface)
+	m.world[2][3] = 8
+	r, _, _ := m.fill(th)
+	sr, _, _, _ := th.Surface.RGBA()
+	if want := sr + (1-sr)*ElevationOverlay(8); r != want {
+		t.Errorf("have fill %v at 8dp, want %v", r, want)
+	}
+	if r, _, _ := m.fill(DefaultTheme); r != sr {
+		t.Errorf("light theme applied elevation overlay")
+	}
+	if r, g, b, _ := m.textColor(th); r != 1 || g != 1 || b != 1 {
+		t.Errorf("have text color %v %v %v on dark surface, want white", r, g, b)
+	}
+	m.SetTextColor(Red500)
+	if r, _, _, _ := m.textColor(th); r == 1 {
+		t.Errorf("text color set by SetTextColor was overridden")
+	}
+}
+
+func TestNewMaterialContrast(t *testing.T) {
+	env := new(Environment)
+	m := env.NewMaterial(nil)
+	if r, _, _, _ := m.textColor(env.Theme()); r != 0 {
+		t.Errorf("have text red %v on surface, want black", r)
+	}
+	m.SetColor(Indigo500)
+	if r, g, b, _ := m.textColor(env.Theme()); r != 1 || g != 1 || b != 1 {
+		t.Errorf("have text color %v %v %v on recolored material, want white", r, g, b)
+	}
+}
@@ -0,0 +1,171 @@
+package material
+
+import (
+	"time"
+
+	"golang.org/x/mobile/exp/f32"
+)
+
+// solved is the layout state of a material; see Environment.solve.
+type solved struct {
+	box               Box
+	roundness         float32
+	hidden, collapsed bool
+}
+
+func (s *solved) save(m *Material) {
+	s.box, s.roundness, s.hidden, s.collapsed = m.Box, m.Roundness, m.hidden, m.collapsed
+}
+
+func (s solved) restore(m *Material) {
+	m.Box, m.Roundness, m.hidden, m.collapsed = s.box, s.roundness, s.hidden, s.collapsed
+}
+
+// solve returns layout state of each sheet for the layout of constraints added
+// by fn between StartLayout and FinishLayout, solved as by FinishLayout. The
+// current layout is restored before returning.
+func (env *Environment) solve(fn func()) map[Sheet]solved {
+	var (
+		lprg = env.lprg
+		box  = env.Box
+		prev = make(map[Sheet]solved, len(env.sheets))
+		next = make(map[Sheet]solved, len(env.sheets))
+	)
+	for _, sheet := range env.sheets {
+		var s solved
+		s.save(sheet.M())
+		prev[sheet] = s
+	}
+
+	env.startLayout()
+	if fn != nil {
+		fn()
+	}
+	env.finishLayout()
+
+	for _, sheet := range env.sheets {
+		var s solved
+		s.save(sheet.M())
+		next[sheet] = s
+		prev[sheet].restore(sheet.M())
+	}
+	env.lprg, env.Box = lprg, box
+	return next
+}
+
+// SolveLayout returns world of each sheet for the layout of constraints added
+// by fn without changing the current layout.
+func (env *Environment) SolveLayout(fn func()) map[Sheet]f32.Mat4 {
+	next := env.solve(fn)
+	worlds := make(map[Sheet]f32.Mat4, len(next))
+	for sheet, s := range next {
+		worlds[sheet] = s.box.world
+	}
+	return worlds
+}
+
+// Transition animates sheets from their current layout to another.
+//
+//	tr := env.NewTransition(300*time.Millisecond, layoutDetail)
+//	tr.Tween(card).Color(White)
+//	env.Animate(tr.Animation())
+type Transition struct {
+	env    *Environment
+	dur    time.Duration
+	layout func()
+	tweens map[*Material]*Tween
+}
+
+// NewTransition returns transition lasting dur to the layout of constraints
+// added by fn. The layout is solved immediately and fn is called again when the
+// transition ends to make the layout current; fn should add the same
+// constraints each time it's called.
+func (env *Environment) NewTransition(dur time.Duration, fn func()) *Transition {
+	tr := &Transition{env: env, dur: dur, layout: fn, tweens: make(map[*Material]*Tween)}
+	for sheet, s := range env.solve(fn) {
+		m := sheet.M()
+		if m.world == s.box.world && m.Roundness == s.roundness {
+			continue
+		}
+		w := s.box.world
+		tw := tr.Tween(m).Position(w[0][3], w[1][3]).Size(w[0][0], w[1][1]).Z(w[2][3])
+		if m.Roundness != s.roundness {
+			tw.Roundness(s.roundness)
+		}
+	}
+	return tr
+}
+
+// Tween returns tween of mtrl for transition so properties not determined by
+// layout, such as color, can be tweened with it. Tween returns nil if mtrl
+// does not belong to the transition's environment.
+func (tr *Transition) Tween(mtrl *Material) *Tween {
+	if mtrl.env != tr.env {
+		return nil
+	}
+	tw, ok := tr.tweens[mtrl]
+	if !ok {
+		tw = NewTween(mtrl, tr.dur)
+		tr.tweens[mtrl] = tw
+	}
+	return tw
+}
+
+// Animation returns animation of transition. The new layout is made current
+// when the animation ends or is cancelled.
+func (tr *Transition) Animation() Animation {
+	var anims []Animation
+	for _, tw := range tr.tweens {
+		anims = append(anims, tw.Animation())
+	}
+	anim := Parallel(anims...)
+	end := anim.End
+	anim.End = func() {
+		end()
+		tr.env.StartLayout()
+		if tr.layout != nil {
+			tr.layout()
+		}
+		tr.env.FinishLayout()
+	}
+	return anim
+}
+
+// ContainerTransform returns animation morphing material from into material
+// to, such as a floating action button expanding into a sheet. On start, from
+// is hidden and to is shown with the position, size, roundness and color of
+// from; these are then tweened to the values to had at start while the text
+// and icon of to fade in.
+func ContainerTransform(from, to *Material, dur time.Duration) Animation {
+	var inner Animation
+	return Animation{
+		Sig: Standard,
+		Dur: dur,
+		Start: func() {
+			tw := NewTween(to, dur)
+			w := to.world
+			tw.Position(w[0][3], w[1][3]).Size(w[0][0], w[1][1]).Z(w[2][3])
+			tw.Roundness(to.Roundness)
+			tw.add(true, []float32{to.cr, to.cg, to.cb}, &to.cr, &to.cg, &to.cb)
+			tw.add(false, []float32{to.ca}, &to.ca)
+			tw.TextAlpha(to.text.a).IconAlpha(to.icon.a)
+
+			to.world, to.Roundness = from.world, from.Roundness
+			to.cr, to.cg, to.cb, to.ca = from.cr, from.cg, from.cb, from.ca
+			to.text.a, to.icon.a = 0, 0
+			from.Hide()
+			to.Show()
+
+			inner = tw.Animation()
+			inner.Start()
+		},
+		Interp: func(dt float32) {
+			inner.Interp(dt)
+		},
+		End: func() {
+			if inner.Interp != nil {
+				inner.Interp(1)
+			}
+		},
+	}
+}
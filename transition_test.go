@@ -0,0 +1,58 @@
+package material
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestContainerTransform(t *testing.T) {
+	from, to := New(nil, Red500), New(nil, White)
+	from.world.Identity()
+	from.world[0][0], from.world[1][1], from.world[2][3] = 56, 56, 6
+	from.Roundness = 28
+	to.world.Identity()
+	to.world[0][3], to.world[0][0], to.world[1][1], to.world[2][3] = 10, 300, 400, 2
+	to.SetTextColor(Black)
+	to.Hide()
+	want := to.world
+
+	anim := ContainerTransform(from, to, 300*time.Millisecond)
+	anim.Start()
+	anim.Interp(0)
+	if !from.Hidden() || to.Hidden() {
+		t.Fatalf("have from hidden %v, to hidden %v; want true, false", from.Hidden(), to.Hidden())
+	}
+	if to.world != from.world || to.Roundness != 28 || to.cr != from.cr || to.text.a != 0 {
+		t.Errorf("container does not start as from: %v %v", to.world, to.Roundness)
+	}
+
+	anim.Interp(1)
+	if to.world != want || to.Roundness != 0 || to.text.a != 1 {
+		t.Errorf("have %v with roundness %v, want %v with 0", to.world, to.Roundness, want)
+	}
+	if r, _, _, _ := White.RGBA(); to.cr < r-1e-6 {
+		t.Errorf("have red %v, want %v", to.cr, r)
+	}
+}
+
+func TestTransitionTweenAfterSort(t *testing.T) {
+	env := new(Environment)
+	a, b := env.NewMaterial(nil), env.NewMaterial(nil)
+	a.world[2][3] = 8
+	env.StartLayout()
+	env.FinishLayout()
+
+	tr := env.NewTransition(time.Second, nil)
+	twa, twb := tr.Tween(a), tr.Tween(b)
+	sort.Sort(byZ(env.sheets)) // as Draw does
+	if tr.Tween(a) != twa || tr.Tween(b) != twb || twa == twb {
+		t.Errorf("tweens changed after sheets sorted")
+	}
+	if tr.Tween(New(nil, White)) != nil {
+		t.Errorf("have tween of material not in environment")
+	}
+	if worlds := env.SolveLayout(nil); len(worlds) != 2 {
+		t.Errorf("have %v solved worlds, want 2", len(worlds))
+	}
+}
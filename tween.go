@@ -0,0 +1,211 @@
+package material
+
+import "time"
+
+// Tween interpolates properties of a material from their values when the tween
+// starts to target values. Colors are interpolated in linear space.
+//
+//	tw := NewTween(mtrl, 300*time.Millisecond).Position(x, y).Color(Red500)
+//	env.Animate(tw.Animation())
+type Tween struct {
+	Sig Curve
+	Dur time.Duration
+
+	mtrl  *Material
+	props []*tweenProp
+}
+
+type tweenProp struct {
+	dst      []*float32
+	from, to []float32
+	linear   bool // values are sRGB components
+}
+
+func (p *tweenProp) start() {
+	for i, x := range p.dst {
+		p.from[i] = *x
+	}
+}
+
+func (p *tweenProp) interp(dt float32) {
+	for i, x := range p.dst {
+		a, b := p.from[i], p.to[i]
+		if p.linear {
+			*x = fromLinear(toLinear(a) + (toLinear(b)-toLinear(a))*dt)
+		} else {
+			*x = a + (b-a)*dt
+		}
+	}
+}
+
+// NewTween returns tween of mtrl lasting dur.
+func NewTween(mtrl *Material, dur time.Duration) *Tween {
+	return &Tween{Sig: Standard, Dur: dur, mtrl: mtrl}
+}
+
+func (tw *Tween) add(linear bool, to []float32, dst ...*float32) *Tween {
+	tw.props = append(tw.props, &tweenProp{dst: dst, from: make([]float32, len(dst)), to: to, linear: linear})
+	return tw
+}
+
+// Position tweens bottom-left corner of material to x, y.
+func (tw *Tween) Position(x, y float32) *Tween {
+	m := tw.mtrl
+	return tw.add(false, []float32{x, y}, &m.world[0][3], &m.world[1][3])
+}
+
+// Size tweens width and height of material to w, h.
+func (tw *Tween) Size(w, h float32) *Tween {
+	m := tw.mtrl
+	return tw.add(false, []float32{w, h}, &m.world[0][0], &m.world[1][1])
+}
+
+// Z tweens z position of material, changing its elevation and shadow.
+func (tw *Tween) Z(z float32) *Tween {
+	return tw.add(false, []float32{z}, &tw.mtrl.world[2][3])
+}
+
+// Color tweens background color of material to c.
+func (tw *Tween) Color(c Color) *Tween {
+	m := tw.mtrl
+	r, g, b, a := c.RGBA()
+	tw.add(true, []float32{r, g, b}, &m.cr, &m.cg, &m.cb)
+	return tw.add(false, []float32{a}, &m.ca)
+}
+
+// Roundness tweens corner radius of material to r.
+func (tw *Tween) Roundness(r float32) *Tween {
+	return tw.add(false, []float32{r}, &tw.mtrl.Roundness)
+}
+
+// Rotate tweens rotation of material to r radians.
+func (tw *Tween) Rotate(r float32) *Tween {
+	return tw.add(false, []float32{r}, &tw.mtrl.Rotate)
+}
+
+// TextAlpha tweens alpha of text color to a.
+func (tw *Tween) TextAlpha(a float32) *Tween {
+	return tw.add(false, []float32{a}, &tw.mtrl.text.a)
+}
+
+// IconAlpha tweens alpha of icon color to a.
+func (tw *Tween) IconAlpha(a float32) *Tween {
+	return tw.add(false, []float32{a}, &tw.mtrl.icon.a)
+}
+
+// Animation returns animation of tween. Starting values are read each time
+// the animation starts.
+func (tw *Tween) Animation() Animation {
+	return Animation{
+		Sig: tw.Sig,
+		Dur: tw.Dur,
+		Start: func() {
+			for _, p := range tw.props {
+				p.start()
+			}
+		},
+		Interp: func(dt float32) {
+			for _, p := range tw.props {
+				p.interp(dt)
+			}
+		},
+	}
+}
+
+// track steps a child of a composite animation.
+type track struct {
+	anim           Animation
+	offset         time.Duration
+	started, ended bool
+	last           float64
+}
+
+func (tr *track) to(t float64) {
+	if !tr.started {
+		tr.started, tr.last = true, -1
+		if tr.anim.Start != nil {
+			tr.anim.Start()
+		}
+	}
+	if t != tr.last {
+		tr.last = t
+		if tr.anim.Interp != nil {
+			dt := float32(t)
+			if tr.anim.Sig != nil {
+				dt = float32(tr.anim.Sig.At(t))
+			}
+			tr.anim.Interp(dt)
+		}
+	}
+	if t == 1 {
+		tr.end()
+	}
+}
+
+func (tr *track) end() {
+	if tr.started && !tr.ended {
+		tr.ended = true
+		if tr.anim.End != nil {
+			tr.anim.End()
+		}
+	}
+}
+
+// composite returns animation stepping tracks to local progress for position
+// pos within dur. Start and End of each track are called at most once.
+func composite(tracks []*track, dur time.Duration) Animation {
+	return Animation{
+		Dur: dur,
+		Interp: func(dt float32) {
+			pos := time.Duration(float64(dt) * float64(dur))
+			// rewind tracks not yet reached first, such as when reversed,
+			// so tracks reached take precedence over shared properties.
+			for _, tr := range tracks {
+				if pos < tr.offset && tr.started {
+					tr.to(0)
+				}
+			}
+			for _, tr := range tracks {
+				if pos < tr.offset {
+					continue
+				}
+				t := float64(1)
+				if d := tr.anim.Dur; d > 0 && pos-tr.offset < d {
+					t = float64(pos-tr.offset) / float64(d)
+				}
+				tr.to(t)
+			}
+		},
+		End: func() {
+			for _, tr := range tracks {
+				tr.end()
+			}
+		},
+	}
+}
+
+// Sequence returns animation playing anims one after another. The returned
+// animation keeps state of its children and should be started only once.
+func Sequence(anims ...Animation) Animation {
+	var dur time.Duration
+	tracks := make([]*track, len(anims))
+	for i, anim := range anims {
+		tracks[i] = &track{anim: anim, offset: dur}
+		dur += anim.Dur
+	}
+	return composite(tracks, dur)
+}
+
+// Parallel returns animation playing anims at the same time, lasting as long
+// as the longest. The returned animation should be started only once.
+func Parallel(anims ...Animation) Animation {
+	var dur time.Duration
+	tracks := make([]*track, len(anims))
+	for i, anim := range anims {
+		tracks[i] = &track{anim: anim}
+		if anim.Dur > dur {
+			dur = anim.Dur
+		}
+	}
+	return composite(tracks, dur)
+}
@@ -0,0 +1,58 @@
+package material
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTweenColorLinear(t *testing.T) {
+	m := New(nil, Black)
+	tw := NewTween(m, time.Second).Color(White)
+	tw.Sig = nil
+	anim := tw.Animation()
+	anim.Start()
+	anim.Interp(0.5)
+	// midpoint of black and white in linear light is brighter than 0.5 in sRGB
+	if m.cr < 0.7 || m.cr > 0.75 || m.cr != m.cg || m.cg != m.cb {
+		t.Errorf("have %v %v %v, want ~0.735", m.cr, m.cg, m.cb)
+	}
+	anim.Interp(1)
+	if r, _, _, a := White.RGBA(); m.cr < r-1e-6 || m.ca != a {
+		t.Errorf("have %v, %v; want %v, %v", m.cr, m.ca, r, a)
+	}
+}
+
+func TestSequenceAndReverse(t *testing.T) {
+	clock := new(testClock)
+	sch := NewScheduler(clock)
+
+	m := New(nil, Black)
+	a := NewTween(m, 100*time.Millisecond).Position(100, 0)
+	b := NewTween(m, 100*time.Millisecond).Position(100, 100)
+	a.Sig, b.Sig = nil, nil
+
+	var ends int
+	seq := Sequence(a.Animation(), b.Animation())
+	seq.End = func() { ends++ }
+	h := sch.Start(seq)
+
+	sch.Step()
+	clock.Add(150 * time.Millisecond)
+	sch.Step()
+	if x, y := m.world[0][3], m.world[1][3]; x != 100 || y != 50 {
+		t.Fatalf("have %v, %v; want 100, 50", x, y)
+	}
+
+	h.Reverse()
+	clock.Add(100 * time.Millisecond)
+	sch.Step()
+	if x, y := m.world[0][3], m.world[1][3]; x != 50 || y != 0 {
+		t.Errorf("have %v, %v; want 50, 0", x, y)
+	}
+
+	clock.Add(time.Second)
+	sch.Step()
+	if x := m.world[0][3]; x != 0 || ends != 1 || sch.Len() != 0 {
+		t.Errorf("have x %v with %v ends and %v scheduled, want 0 with 1 and 0", x, ends, sch.Len())
+	}
+}